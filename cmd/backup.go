@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/container"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/backup"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var backupDatabaseCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "备份数据库",
+	Long:  `使用 pg_dump 导出数据库的一致性逻辑备份(schema + data，压缩)。指定 --output 时写入本地文件，否则上传到对象存储。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		ctx := cmd.Context()
+		output := lo.Must1(cmd.Flags().GetString("output"))
+
+		if output != "" {
+			result := lo.Must1(backup.Dump(ctx, output))
+			logger.Logger().Info("[Backup] backup written", zap.String("path", result.Path), zap.String("checksum", result.Checksum))
+			return
+		}
+
+		objDAO := lo.Must1(container.New().BackupObjDAO())
+		result := lo.Must1(backup.ToStorage(ctx, objDAO))
+		logger.Logger().Info("[Backup] backup uploaded", zap.String("name", result.Name), zap.String("checksum", result.Checksum))
+	},
+}
+
+var listBackupCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出对象存储中的备份",
+	Long:  `按时间倒序列出对象存储中的数据库备份。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		objDAO := lo.Must1(container.New().BackupObjDAO())
+		backups := lo.Must1(backup.List(cmd.Context(), objDAO))
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tSIZE\tLAST MODIFIED")
+		for _, b := range backups {
+			fmt.Fprintf(w, "%s\t%d\t%s\n", b.ObjectName, b.Size, b.LastModified.Format(time.DateTime))
+		}
+		lo.Must0(w.Flush())
+	},
+}
+
+var pruneBackupCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "按保留规则清理旧备份",
+	Long:  `按 BACKUP_RETENTION_COUNT 与 BACKUP_RETENTION_DAYS 清理对象存储中的旧备份，最新的备份永远保留。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		objDAO := lo.Must1(container.New().BackupObjDAO())
+		maxAge := time.Duration(config.BackupRetentionDays) * 24 * time.Hour
+		deleted := lo.Must1(backup.ApplyRetention(cmd.Context(), objDAO, config.BackupRetentionCount, maxAge))
+		logger.Logger().Info("[Backup] prune finished", zap.Strings("deleted", deleted))
+	},
+}
+
+var restoreDatabaseCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "恢复数据库",
+	Long:  `将备份恢复到空数据库。指定 --input 时使用本地文件，否则从对象存储下载 --name 指定的备份(默认最新)。恢复前校验校验和与归档完整性，目标库非空时拒绝执行。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		ctx := cmd.Context()
+		input := lo.Must1(cmd.Flags().GetString("input"))
+		name := lo.Must1(cmd.Flags().GetString("name"))
+
+		c := container.New()
+		lo.Must0(c.InitDatabase())
+
+		if input != "" {
+			lo.Must0(backup.Restore(ctx, input))
+			return
+		}
+
+		lo.Must0(backup.FromStorage(ctx, lo.Must1(c.BackupObjDAO()), name))
+	},
+}
+
+func init() {
+	backupDatabaseCmd.Flags().StringP("output", "o", "", "备份输出的本地文件路径，为空时上传到对象存储")
+	restoreDatabaseCmd.Flags().StringP("input", "i", "", "本地备份文件路径")
+	restoreDatabaseCmd.Flags().StringP("name", "n", "", "对象存储中的备份名，为空时使用最新备份")
+	restoreDatabaseCmd.MarkFlagsMutuallyExclusive("input", "name")
+
+	backupDatabaseCmd.AddCommand(listBackupCmd, pruneBackupCmd)
+	databaseCmd.AddCommand(backupDatabaseCmd, restoreDatabaseCmd)
+}
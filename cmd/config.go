@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultEnvTemplatePath  = "env/api.env.template"
+	defaultYAMLTemplatePath = "env/config.yaml.template"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "配置相关命令组",
+	Long:  `提供一组用于查看、校验配置以及生成配置模板的命令。`,
+}
+
+var showConfigCmd = &cobra.Command{
+	Use:   "show",
+	Short: "查看生效的配置",
+	Long: `按定义顺序列出所有配置项的配置键、环境变量名、生效值与来源(default/file/env/env_file)，
+敏感配置已设置时脱敏显示。指定 --changed 时只列出不是来自默认值的配置项。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		changed := lo.Must1(cmd.Flags().GetBool("changed"))
+
+		if files := config.Files(); len(files) > 0 {
+			fmt.Printf("Config files: %s\n\n", strings.Join(files, ", "))
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "KEY\tENV\tVALUE\tSOURCE")
+		for _, field := range config.Fields(config.Current()) {
+			source := config.Source(field.Key)
+			if changed && source == config.SourceDefault {
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", field.Key, field.Env, lo.Ternary(source == "", "-", field.Format()), lo.Ternary(source == "", "invalid", source))
+		}
+		lo.Must0(w.Flush())
+	},
+}
+
+var validateConfigCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "校验配置",
+	Long:  `按启动服务时的规则校验当前环境下的配置，列出所有问题并以非零状态码退出，适用于CI与部署前检查。`,
+	Run: func(_ *cobra.Command, _ []string) {
+		err := config.Validate(config.Current())
+		if err == nil {
+			logger.Logger().Info("[Config] config is valid", zap.Strings("files", config.Files()))
+			return
+		}
+
+		var validationErr *config.ValidationError
+		if !errors.As(err, &validationErr) {
+			lo.Must0(err)
+		}
+		for _, problem := range validationErr.Problems {
+			logger.Logger().Error("[Config] invalid config", zap.String("problem", problem))
+		}
+		os.Exit(1)
+	},
+}
+
+var templateConfigCmd = &cobra.Command{
+	Use:   "template",
+	Short: "生成配置模板",
+	Long: `根据配置定义生成环境变量模板与 YAML 配置文件示例，保证模板与代码中的配置项一致。
+指定 --check 时只检查模板是否需要重新生成，需要时以非零状态码退出，适用于CI。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		envPath := lo.Must1(cmd.Flags().GetString("env-file"))
+		yamlPath := lo.Must1(cmd.Flags().GetString("yaml-file"))
+		check := lo.Must1(cmd.Flags().GetBool("check"))
+
+		templates := map[string][]byte{
+			envPath:  config.EnvTemplate(),
+			yamlPath: lo.Must1(config.YAMLTemplate()),
+		}
+
+		outdated := 0
+		for _, path := range []string{envPath, yamlPath} {
+			existing, err := os.ReadFile(path)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				lo.Must0(err)
+			}
+			if bytes.Equal(existing, templates[path]) {
+				continue
+			}
+
+			if check {
+				logger.Logger().Error("[Config] template is outdated, run `config template` to regenerate it", zap.String("path", path))
+				outdated++
+				continue
+			}
+			lo.Must0(os.WriteFile(path, templates[path], 0o644))
+			logger.Logger().Info("[Config] template written", zap.String("path", path))
+		}
+
+		if outdated > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	showConfigCmd.Flags().Bool("changed", false, "只列出不是来自默认值的配置项")
+
+	templateConfigCmd.Flags().String("env-file", defaultEnvTemplatePath, "环境变量模板的输出路径")
+	templateConfigCmd.Flags().String("yaml-file", defaultYAMLTemplatePath, "YAML 配置文件示例的输出路径")
+	templateConfigCmd.Flags().Bool("check", false, "只检查模板是否需要重新生成，不写入文件")
+
+	configCmd.AddCommand(showConfigCmd, validateConfigCmd, templateConfigCmd)
+	rootCmd.AddCommand(configCmd)
+}
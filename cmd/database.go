@@ -1,12 +1,25 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/container"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
 	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/migration"
 	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 )
 
+const defaultMigrationDir = "internal/resource/database/migration/sql"
+
 var databaseCmd = &cobra.Command{
 	Use:   "database",
 	Short: "数据库相关命令组",
@@ -16,15 +29,125 @@ var databaseCmd = &cobra.Command{
 var migrateDatabaseCmd = &cobra.Command{
 	Use:   "migrate",
 	Short: "迁移数据库",
-	Long:  `执行数据库迁移操作，将数据库结构更新到最新的模式。`,
+	Long:  `执行数据库迁移操作，将数据库结构更新到最新的模式。不带子命令时等同于 migrate up。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		migrateUpCmd.Run(cmd, args)
+	},
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "执行所有未执行的迁移",
+	Long:  `按版本顺序执行所有未执行的迁移，执行期间持有数据库咨询锁防止并发迁移。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		migrator := newMigrator(cmd)
+
+		applied := lo.Must1(migrator.Up(cmd.Context()))
+		logger.Logger().Info("[Migration] up finished", zap.Int("applied", len(applied)))
+
+		if config.PostgresTenantRLS && config.DatabaseDriver == database.DriverPostgres {
+			lo.Must0(database.EnableTenantRLS(database.GetDBInstance(cmd.Context()), model.Models...))
+		}
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down [N]",
+	Short: "回滚最近的 N 个迁移",
+	Long:  `按版本倒序回滚最近执行的 N 个迁移，N 默认为 1。`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		steps := 1
+		if len(args) == 1 {
+			steps = lo.Must1(strconv.Atoi(args[0]))
+		}
+		if steps <= 0 {
+			lo.Must0(fmt.Errorf("steps must be positive, got %d", steps))
+		}
+
+		migrator := newMigrator(cmd)
+		reverted := lo.Must1(migrator.Down(cmd.Context(), steps))
+		logger.Logger().Info("[Migration] down finished", zap.Int("reverted", len(reverted)))
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "查看迁移状态",
+	Long:  `列出所有迁移及其执行状态。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		migrator := newMigrator(cmd)
+		statuses := lo.Must1(migrator.Status(cmd.Context()))
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, status := range statuses {
+			state, appliedAt := "pending", "-"
+			if status.Applied {
+				state, appliedAt = "applied", status.AppliedAt.Format(time.DateTime)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+		}
+		lo.Must0(w.Flush())
+	},
+}
+
+var migrateCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "创建SQL迁移文件",
+	Long:  `在迁移目录下生成一对以当前时间为版本号的 up/down SQL 文件，重新编译后即被内嵌到二进制中。指定 --dialect 时生成仅对该数据库生效的文件。`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := lo.Must1(cmd.Flags().GetString("dir"))
+		dialect := lo.Must1(cmd.Flags().GetString("dialect"))
+		upPath, downPath := lo.Must2(migration.Create(dir, args[0], dialect))
+		logger.Logger().Info("[Migration] migration created", zap.String("up", upPath), zap.String("down", downPath))
+	},
+}
+
+var migrateBaselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "为已有数据库建立迁移基线",
+	Long:  `将指定版本及之前的迁移标记为已执行而不真正执行，用于接入此前通过 AutoMigrate 建表的数据库。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		version := lo.Must1(cmd.Flags().GetInt64("version"))
+
+		migrator := newMigrator(cmd)
+		marked := lo.Must1(migrator.Baseline(cmd.Context(), version))
+		logger.Logger().Info("[Migration] baseline finished", zap.Int("marked", len(marked)))
+	},
+}
+
+var migrateCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "检查模型与迁移是否漂移",
+	Long:  `检查是否存在未执行的迁移，以及数据库表结构与 model.Models 是否一致，存在问题时以非零状态码退出，适用于CI。`,
 	Run: func(cmd *cobra.Command, _ []string) {
-		database.InitDatabase()
-		db := database.GetDBInstance(cmd.Context())
-		lo.Must0(db.AutoMigrate(model.Models...))
+		migrator := newMigrator(cmd)
+		problems := lo.Must1(migrator.Check(cmd.Context(), model.Models...))
+		if len(problems) == 0 {
+			logger.Logger().Info("[Migration] check passed")
+			return
+		}
+
+		for _, problem := range problems {
+			logger.Logger().Error("[Migration] drift detected", zap.String("problem", problem))
+		}
+		os.Exit(1)
 	},
 }
 
+func newMigrator(cmd *cobra.Command) *migration.Migrator {
+	lo.Must0(container.New().InitDatabase())
+	return lo.Must1(migration.NewMigrator(database.GetDBInstance(cmd.Context())))
+}
+
 func init() {
+	migrateCreateCmd.Flags().String("dir", defaultMigrationDir, "SQL迁移文件目录")
+	migrateCreateCmd.Flags().String("dialect", "", "数据库方言(postgres/mysql/sqlite)，为空时对所有数据库生效")
+	migrateBaselineCmd.Flags().Int64("version", 0, "基线版本，默认为全部迁移")
+
+	migrateDatabaseCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateStatusCmd, migrateCreateCmd, migrateBaselineCmd, migrateCheckCmd)
 	databaseCmd.AddCommand(migrateDatabaseCmd)
 	rootCmd.AddCommand(databaseCmd)
 }
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"os"
+	"slices"
+
+	"github.com/hcd233/go-backend-tmpl/internal/i18n"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const defaultCatalogDir = "internal/i18n/locales"
+
+var i18nCmd = &cobra.Command{
+	Use:   "i18n",
+	Short: "国际化相关命令组",
+	Long:  `提供一组用于维护消息目录的命令。`,
+}
+
+var i18nExtractCmd = &cobra.Command{
+	Use:   "extract",
+	Short: "检查消息目录中缺失的键",
+	Long: `扫描源码中引用的消息键（错误码、WithMessageKey、i18n.T/Plural 以及 binding 校验规则），
+与各语言的消息目录比对，并检查各语言之间的键是否一致。存在缺失时以非零状态码退出，适用于CI；
+指定 --write 时将缺失的键以空消息写入目录，空消息在运行时回退到默认语言。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		src := lo.Must1(cmd.Flags().GetString("src"))
+		dir := lo.Must1(cmd.Flags().GetString("dir"))
+		write := lo.Must1(cmd.Flags().GetBool("write"))
+
+		keys := lo.Must1(i18n.Extract(src))
+
+		catalogs := map[string]map[string]map[string]string{}
+		for _, locale := range i18n.Supported() {
+			catalogs[locale] = lo.Must1(i18n.LoadCatalogFile(i18n.CatalogPath(dir, locale)))
+			// 其他语言已有的键同样需要翻译
+			keys = append(keys, lo.Keys(catalogs[locale])...)
+		}
+		keys = lo.Uniq(keys)
+		slices.Sort(keys)
+
+		total := 0
+		for locale, catalog := range catalogs {
+			missing := i18n.Missing(catalog, keys)
+			for _, key := range missing {
+				logger.Logger().Warn("[I18n] missing message", zap.String("locale", locale), zap.String("key", key))
+				if _, ok := catalog[key]; !ok {
+					catalog[key] = map[string]string{}
+				}
+			}
+			total += len(missing)
+
+			if write && len(missing) > 0 {
+				lo.Must0(i18n.WriteCatalogFile(i18n.CatalogPath(dir, locale), catalog))
+				logger.Logger().Info("[I18n] catalog updated", zap.String("locale", locale), zap.Int("added", len(missing)))
+			}
+		}
+
+		if total == 0 {
+			logger.Logger().Info("[I18n] all messages translated", zap.Int("keys", len(keys)))
+			return
+		}
+		if !write {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	i18nExtractCmd.Flags().String("src", ".", "扫描的源码目录")
+	i18nExtractCmd.Flags().String("dir", defaultCatalogDir, "消息目录所在目录")
+	i18nExtractCmd.Flags().Bool("write", false, "将缺失的键以空消息写入消息目录")
+
+	i18nCmd.AddCommand(i18nExtractCmd)
+	rootCmd.AddCommand(i18nCmd)
+}
@@ -3,9 +3,8 @@ package cmd
 import (
 	"context"
 
+	"github.com/hcd233/go-backend-tmpl/internal/container"
 	"github.com/hcd233/go-backend-tmpl/internal/logger"
-	"github.com/hcd233/go-backend-tmpl/internal/resource/storage"
-	objdao "github.com/hcd233/go-backend-tmpl/internal/resource/storage/obj_dao"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -30,15 +29,15 @@ var createBucketCmd = &cobra.Command{
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx := context.Background()
 		logger := logger.Logger()
-		storage.InitObjectStorage()
+		c := container.New()
 
-		imageObjDAO := objdao.GetImageObjDAO()
+		imageObjDAO := lo.Must1(c.ImageObjDAO())
 		lo.Must0(imageObjDAO.CreateBucket(ctx))
 
 		logger.Info("[Object Storage] Bucket created",
 			zap.String("bucket", imageObjDAO.GetBucketName(ctx)))
 
-		thumbnailObjDAO := objdao.GetThumbnailObjDAO()
+		thumbnailObjDAO := lo.Must1(c.ThumbnailObjDAO())
 		lo.Must0(thumbnailObjDAO.CreateBucket(ctx))
 
 		logger.Info("[Object Storage] Bucket created",
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"github.com/hcd233/go-backend-tmpl/internal/container"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/seed"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var seedDatabaseCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "写入种子数据",
+	Long: `从 YAML/JSON 夹具文件以及确定性的假数据生成器写入种子数据，按唯一键 upsert，可重复执行。
+仅允许在 APP_ENV 为 development/test/local 时执行，其他环境需要指定 --force 并输入 APP_ENV 的值确认。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		force := lo.Must1(cmd.Flags().GetBool("force"))
+		ctx := lo.Must1(seed.Confirm(cmd.Context(), force, cmd.InOrStdin(), cmd.OutOrStdout()))
+
+		files := lo.Must1(cmd.Flags().GetStringSlice("file"))
+		fakeUsers := lo.Must1(cmd.Flags().GetInt("fake-users"))
+		randSeed := lo.Must1(cmd.Flags().GetInt64("seed"))
+
+		lo.Must0(container.New().InitDatabase())
+
+		for _, file := range files {
+			fixtures := lo.Must1(seed.LoadFixtureFile(file))
+			affected := lo.Must1(seed.ApplyFixtures(ctx, fixtures))
+			logger.Logger().Info("[Seed] fixture file loaded", zap.String("file", file), zap.Int64("affected", affected))
+		}
+
+		if fakeUsers > 0 {
+			affected := lo.Must1(seed.SeedUsers(ctx, seed.FakeUsers(randSeed, fakeUsers)))
+			logger.Logger().Info("[Seed] fake users generated", zap.Int("count", fakeUsers), zap.Int64("seed", randSeed), zap.Int64("affected", affected))
+		}
+	},
+}
+
+var resetDatabaseCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "重置本地数据库",
+	Long: `删除 model.Models 对应的表与迁移记录后重新执行全部迁移，仅用于本地开发。
+仅允许在 APP_ENV 为 development/test/local 时执行，其他环境需要指定 --force 并输入 APP_ENV 的值确认。`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		force := lo.Must1(cmd.Flags().GetBool("force"))
+		lo.Must1(seed.Confirm(cmd.Context(), force, cmd.InOrStdin(), cmd.OutOrStdout()))
+
+		migrator := newMigrator(cmd)
+		applied := lo.Must1(migrator.Reset(cmd.Context(), model.Models...))
+		logger.Logger().Info("[Migration] reset finished", zap.Int("applied", len(applied)))
+	},
+}
+
+func init() {
+	seedDatabaseCmd.Flags().StringSliceP("file", "f", nil, "夹具文件路径，可重复指定")
+	seedDatabaseCmd.Flags().Int("fake-users", 0, "生成的假用户数量")
+	seedDatabaseCmd.Flags().Int64("seed", 20261018, "假数据生成器的随机种子，相同种子生成相同数据")
+	seedDatabaseCmd.Flags().Bool("force", false, "允许在 development/test/local 以外的环境执行，仍需交互确认")
+	resetDatabaseCmd.Flags().Bool("force", false, "允许在 development/test/local 以外的环境执行，仍需交互确认")
+
+	databaseCmd.AddCommand(seedDatabaseCmd, resetDatabaseCmd)
+}
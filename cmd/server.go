@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"runtime/debug"
@@ -9,16 +10,18 @@ import (
 	"github.com/bytedance/sonic"
 	"github.com/gofiber/fiber/v2"
 	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/container"
 	"github.com/hcd233/go-backend-tmpl/internal/cron"
+	"github.com/hcd233/go-backend-tmpl/internal/health"
+	"github.com/hcd233/go-backend-tmpl/internal/lifecycle"
 	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/metrics"
 	"go.uber.org/zap"
 
 	"github.com/hcd233/go-backend-tmpl/internal/middleware"
-	"github.com/hcd233/go-backend-tmpl/internal/resource/cache"
-	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
-	"github.com/hcd233/go-backend-tmpl/internal/resource/llm"
-	"github.com/hcd233/go-backend-tmpl/internal/resource/storage"
 	"github.com/hcd233/go-backend-tmpl/internal/router"
+	"github.com/hcd233/go-backend-tmpl/internal/tracing"
+	"github.com/hcd233/go-backend-tmpl/internal/util"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
@@ -32,48 +35,160 @@ var serverCmd = &cobra.Command{
 var startServerCmd = &cobra.Command{
 	Use:   "start",
 	Short: "启动API服务器",
-	Long:  `启动并运行API服务器，监听指定的主机和端口`,
+	Long:  `启动并运行API服务器，监听指定的主机和端口，收到 SIGINT / SIGTERM 后在 SHUTDOWN_TIMEOUT 内优雅退出`,
 	Run: func(cmd *cobra.Command, _ []string) {
 		defer func() {
 			if r := recover(); r != nil {
 				logger.Logger().Error("[Server] Start server panic", zap.Any("error", r), zap.ByteString("stack", debug.Stack()))
 				os.Exit(1)
 			}
-			os.Exit(0)
 		}()
 		host, port := lo.Must1(cmd.Flags().GetString("host")), lo.Must1(cmd.Flags().GetString("port"))
 
-		database.InitDatabase()
-		cache.InitCache()
-		storage.InitObjectStorage()
-		llm.InitOpenAIClient()
-		cron.InitCronJobs()
-
-		app := fiber.New(fiber.Config{
-			Prefork:      false,
-			ReadTimeout:  config.ReadTimeout,
-			WriteTimeout: config.WriteTimeout,
-			IdleTimeout:  120 * time.Second,
-			JSONEncoder:  sonic.Marshal,
-			JSONDecoder:  sonic.Unmarshal,
-		})
-
-		// 中间件
-		app.Use(
-			middleware.FgprofMiddleware(),
-			middleware.TraceMiddleware(),
-			middleware.LogMiddleware(),
-			middleware.CORSMiddleware(),
-			middleware.CompressMiddleware(),
-			middleware.RecoverMiddleware(),
-		)
+		if err := config.Validate(config.Current()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if config.MetricsEnabled && config.MetricsPort == port {
+			fmt.Fprintf(os.Stderr, "metrics.port must differ from the API port %s, leave it empty to mount /metrics on the API port\n", port)
+			os.Exit(1)
+		}
+		watchConfig()
+
+		c := container.New()
 
-		router.RegisterRouter(app)
+		// 按依赖顺序启动，退出时按相反顺序停止：先停止接收请求与定时任务，再关闭连接，最后上报剩余的链路
+		manager := lifecycle.NewManager(config.ShutdownTimeout)
+		manager.Append(tracing.LifecycleHook())
+		manager.Append(c.ResourceHooks()...)
+		if config.MetricsEnabled {
+			manager.Append(metrics.LifecycleHook())
+		}
+		manager.Append(
+			cron.LifecycleHook(c.BackupObjDAO),
+			listenHook(manager, "server", fmt.Sprintf("%s:%s", host, port), func() *fiber.App {
+				return newServerApp(c)
+			}),
+		)
+		if config.MetricsEnabled && config.MetricsPort != "" {
+			manager.Append(listenHook(manager, "metricsServer", fmt.Sprintf("%s:%s", host, config.MetricsPort), newMetricsApp))
+		}
+		// 最后注册、最先停止：退出时先将就绪检查置为未就绪
+		manager.Append(health.LifecycleHook())
 
-		lo.Must0(app.Listen(fmt.Sprintf("%s:%s", host, port)))
+		if err := manager.Run(context.Background()); err != nil {
+			logger.Logger().Error("[Server] Server exited with error", zap.Error(err))
+			os.Exit(1)
+		}
+		logger.Logger().Info("[Server] Server exited")
 	},
 }
 
+// watchConfig 监听配置文件变化并记录热更新结果
+//
+//	author centonhuang
+//	update 2026-10-18 18:28:02
+func watchConfig() {
+	config.OnReload(func(event config.ReloadEvent) {
+		if event.Err != nil {
+			logger.Logger().Error("[Config] Reload rejected, keeping the current config", zap.Error(event.Err))
+			return
+		}
+		if len(event.Applied) > 0 {
+			logger.Logger().Info("[Config] Reloaded", zap.Strings("applied", event.Applied))
+		}
+		if len(event.Ignored) > 0 {
+			logger.Logger().Warn("[Config] Changes require a restart to take effect", zap.Strings("keys", event.Ignored))
+		}
+	})
+	if config.WatchConfig() {
+		logger.Logger().Info("[Config] Watching config file for changes")
+	}
+}
+
+// newServerApp 创建API服务
+//
+//	param c *container.Container
+//	return *fiber.App
+//	author centonhuang
+//	update 2026-10-18 19:31:20
+func newServerApp(c *container.Container) *fiber.App {
+	app := fiber.New(fiber.Config{
+		Prefork:      false,
+		ReadTimeout:  config.ReadTimeout,
+		WriteTimeout: config.WriteTimeout,
+		IdleTimeout:  120 * time.Second,
+		JSONEncoder:  sonic.Marshal,
+		JSONDecoder:  sonic.Unmarshal,
+		ErrorHandler: util.ErrorHandler,
+	})
+
+	// 中间件
+	app.Use(
+		middleware.FgprofMiddleware(),
+		middleware.TraceMiddleware(),
+	)
+	if config.MetricsEnabled {
+		app.Use(middleware.MetricsMiddleware())
+	}
+	app.Use(
+		middleware.LogMiddleware(),
+		middleware.CORSMiddleware(),
+		middleware.CompressMiddleware(),
+		middleware.RecoverMiddleware(),
+	)
+
+	router.RegisterRouter(app, c)
+	return app
+}
+
+// newMetricsApp 创建独立管理端口上的指标服务，避免指标暴露在对外的 API 端口上
+//
+//	return *fiber.App
+//	author centonhuang
+//	update 2026-10-18 17:45:08
+func newMetricsApp() *fiber.App {
+	app := fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+		ErrorHandler:          util.ErrorHandler,
+	})
+	app.Get("/metrics", metrics.Handler())
+	return app
+}
+
+// listenHook 在后台监听 addr 的 HTTP 服务，监听失败时通知 manager 退出，停止时等待处理中的请求完成
+//
+//	param manager *lifecycle.Manager
+//	param name string
+//	param addr string
+//	param newApp func() *fiber.App 在依赖的资源启动后才创建服务
+//	return lifecycle.Hook
+//	author centonhuang
+//	update 2026-10-18 17:45:14
+func listenHook(manager *lifecycle.Manager, name, addr string, newApp func() *fiber.App) lifecycle.Hook {
+	var app *fiber.App
+	return lifecycle.Hook{
+		Name: name,
+		OnStart: func(context.Context) error {
+			app = newApp()
+			go func() {
+				if err := app.Listen(addr); err != nil {
+					manager.Fail(name, err)
+				}
+			}()
+			logger.Logger().Info("[Server] Listening", zap.String("name", name), zap.String("addr", addr))
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			timeout := config.ShutdownTimeout
+			if deadline, ok := ctx.Deadline(); ok {
+				timeout = time.Until(deadline)
+			}
+			return app.ShutdownWithTimeout(timeout)
+		},
+	}
+}
+
 func init() {
 	serverCmd.AddCommand(startServerCmd)
 	rootCmd.AddCommand(serverCmd)
@@ -34,6 +34,20 @@ type jwtTokenSigner struct {
 	JwtTokenExpired time.Duration
 }
 
+// NewJwtTokenSigner 创建JWT token 生成器
+//
+//	param secret string
+//	param expired time.Duration
+//	return JwtTokenSigner
+//	author centonhuang
+//	update 2026-10-18 18:02:02
+func NewJwtTokenSigner(secret string, expired time.Duration) JwtTokenSigner {
+	return &jwtTokenSigner{
+		JwtTokenSecret:  secret,
+		JwtTokenExpired: expired,
+	}
+}
+
 // EncodeToken 生成JWT token
 //
 //	param userID uint
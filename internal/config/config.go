@@ -1,15 +1,20 @@
 // Package config provides the configuration
+//
+//	配置由 Config 的结构体定义，按 默认值 < CONFIG_FILE 配置文件 < profile 配置文件 < 环境变量 < XXX_FILE 的优先级加载。
+//	不可热更新的配置在启动时写入包级变量；可热更新的配置(日志级别、跨域来源、限频)通过 Current 读取。
+//
+//	update 2026-10-18 18:25:02
 package config
 
 import (
-	"strings"
 	"time"
-
-	"github.com/spf13/viper"
 )
 
 var (
 
+	// AppEnv string 运行环境，仅 development/test/local 下允许直接写入种子数据等危险操作
+	AppEnv string
+
 	// ReadTimeout time Gin读取超时时间
 	//	update 2024-06-22 08:59:40
 	ReadTimeout time.Duration
@@ -18,14 +23,13 @@ var (
 	//	update 2024-06-22 08:59:37
 	WriteTimeout time.Duration
 
+	// ShutdownTimeout time.Duration 优雅退出的总截止时间，包括等待处理中的请求、定时任务与关闭连接
+	ShutdownTimeout time.Duration
+
 	// MaxHeaderBytes int Gin最大头部字节数
 	//	update 2024-06-22 08:59:34
 	MaxHeaderBytes int
 
-	// LogLevel string 日志级别
-	//	update 2024-06-22 08:59:29
-	LogLevel string
-
 	// LogDirPath string 日志目录路径
 	//	update 2024-06-22 08:59:26
 	LogDirPath string
@@ -64,6 +68,36 @@ var (
 	// Oauth2GoogleRedirectURL string Google OAuth2 Redirect URL
 	Oauth2GoogleRedirectURL string
 
+	// DatabaseDriver string 数据库驱动，可选 postgres / mysql / sqlite
+	DatabaseDriver string
+
+	// DatabaseTimeZone string 数据库连接时区
+	DatabaseTimeZone string
+
+	// DatabaseMaxIdleConns int 最大空闲连接数
+	DatabaseMaxIdleConns int
+
+	// DatabaseMaxOpenConns int 最大打开连接数
+	DatabaseMaxOpenConns int
+
+	// DatabaseConnMaxLifetime time.Duration 连接最大存活时间
+	DatabaseConnMaxLifetime time.Duration
+
+	// DatabaseConnMaxIdleTime time.Duration 连接最大空闲时间
+	DatabaseConnMaxIdleTime time.Duration
+
+	// DatabaseReplicas []string 只读副本地址列表(host:port)，副本与主库使用相同的账号和库名
+	DatabaseReplicas []string
+
+	// DatabaseReplicaMaxLag time.Duration 副本允许的最大复制延迟，超过后读请求回退主库
+	DatabaseReplicaMaxLag time.Duration
+
+	// DatabaseReplicaCheckInterval time.Duration 副本健康检查间隔
+	DatabaseReplicaCheckInterval time.Duration
+
+	// DatabaseReplicaStickyWindow time.Duration 用户写请求成功后，其读请求走主库的时长
+	DatabaseReplicaStickyWindow time.Duration
+
 	// PostgresUser string Postgres用户名
 	//	update 2024-06-22 09:00:30
 	PostgresUser string
@@ -88,6 +122,36 @@ var (
 	//	update 2024-06-22 09:01:50
 	PostgresSSLMode string
 
+	// PostgresTenantRLS bool 是否启用Postgres行级安全策略做租户隔离
+	PostgresTenantRLS bool
+
+	// MysqlUser string MySQL用户名
+	MysqlUser string
+
+	// MysqlPassword string MySQL密码
+	MysqlPassword string
+
+	// MysqlHost string MySQL主机
+	MysqlHost string
+
+	// MysqlPort string MySQL端口
+	MysqlPort string
+
+	// MysqlDatabase string MySQL数据库
+	MysqlDatabase string
+
+	// SqlitePath string SQLite数据库文件路径，:memory: 表示内存数据库
+	SqlitePath string
+
+	// BackupCron string 定时备份的cron表达式，为空表示不开启
+	BackupCron string
+
+	// BackupRetentionCount int 保留的备份个数
+	BackupRetentionCount int
+
+	// BackupRetentionDays int 备份保留天数
+	BackupRetentionDays int
+
 	// RedisHost string Redis主机
 	RedisHost string
 
@@ -97,6 +161,99 @@ var (
 	// RedisPassword string Redis密码
 	RedisPassword string
 
+	// RedisMode string Redis部署模式，可选 single / sentinel / cluster
+	RedisMode string
+
+	// RedisAddrs []string 哨兵或集群节点地址列表，为空时使用 RedisHost:RedisPort
+	RedisAddrs []string
+
+	// RedisUsername string Redis ACL 用户名
+	RedisUsername string
+
+	// RedisDB int Redis数据库编号，集群模式下只能为0
+	RedisDB int
+
+	// RedisSentinelMaster string 哨兵模式的主节点名称
+	RedisSentinelMaster string
+
+	// RedisSentinelUsername string 哨兵 ACL 用户名
+	RedisSentinelUsername string
+
+	// RedisSentinelPassword string 哨兵密码
+	RedisSentinelPassword string
+
+	// RedisTLS bool 是否使用TLS连接Redis
+	RedisTLS bool
+
+	// RedisTLSServerName string TLS校验的服务器名称，为空时使用连接地址
+	RedisTLSServerName string
+
+	// RedisTLSCAFile string 校验服务端证书的CA文件，为空时使用系统根证书
+	RedisTLSCAFile string
+
+	// RedisTLSCertFile string 客户端证书文件，双向TLS时使用
+	RedisTLSCertFile string
+
+	// RedisTLSKeyFile string 客户端私钥文件，双向TLS时使用
+	RedisTLSKeyFile string
+
+	// RedisTLSInsecureSkipVerify bool 跳过服务端证书校验，仅用于测试
+	RedisTLSInsecureSkipVerify bool
+
+	// RedisKeyPrefix string 所有Redis键与频道的前缀，用于多个环境共用同一个Redis，如 staging:
+	RedisKeyPrefix string
+
+	// CacheDAOTTL time.Duration DAO按ID查询的缓存时间，不为正时关闭缓存
+	CacheDAOTTL time.Duration
+
+	// CacheDAONegativeTTL time.Duration 数据不存在时的负缓存时间，不为正时不做负缓存
+	CacheDAONegativeTTL time.Duration
+
+	// CacheLocalSize int 进程内缓存的最大条数，不为正时关闭进程内缓存
+	CacheLocalSize int
+
+	// CacheLocalTTL time.Duration 进程内缓存的最长时间，兜底丢失的失效通知
+	CacheLocalTTL time.Duration
+
+	// CacheNamespaceTTLs map[string]time.Duration 按命名空间覆盖缓存时间，格式为 name=秒数,name=秒数
+	CacheNamespaceTTLs map[string]time.Duration
+
+	// IdempotencyTTL time.Duration 幂等键对应响应的保存时间
+	IdempotencyTTL time.Duration
+
+	// DefaultLocale string 用户未设置语言且请求未携带 Accept-Language 时使用的语言
+	DefaultLocale string
+
+	// OtelServiceName string 链路追踪上报的服务名
+	OtelServiceName string
+
+	// OtelExporterOTLPEndpoint string OTLP HTTP 上报地址，如 http://localhost:4318，为空时不上报链路
+	OtelExporterOTLPEndpoint string
+
+	// OtelExporterOTLPHeaders map[string]string OTLP 上报时附加的请求头，如鉴权令牌
+	OtelExporterOTLPHeaders map[string]string
+
+	// OtelTracesSamplerArg float64 未携带上游采样决定的请求的采样比例，0-1
+	OtelTracesSamplerArg float64
+
+	// HealthCheckTimeout time.Duration 就绪检查中单个依赖检查的超时时间
+	HealthCheckTimeout time.Duration
+
+	// HealthCacheTTL time.Duration 就绪检查结果的缓存时间，避免频繁探测给依赖带来压力
+	HealthCacheTTL time.Duration
+
+	// HealthOptionalChecks []string 非关键依赖，不可用时就绪检查返回降级但仍可接收流量
+	HealthOptionalChecks []string
+
+	// HealthDrainDelay time.Duration 停止时标记为未就绪后等待的时间，让负载均衡摘除实例后再停止接收请求
+	HealthDrainDelay time.Duration
+
+	// MetricsEnabled bool 是否记录并暴露 Prometheus 指标
+	MetricsEnabled bool
+
+	// MetricsPort string 指标服务的独立管理端口，为空时 /metrics 挂载在 API 端口上
+	MetricsPort string
+
 	// MinioEndpoint string Minio Endpoint
 	MinioEndpoint string
 
@@ -157,71 +314,128 @@ var (
 )
 
 func init() {
-	initEnvironment()
+	result := load()
+	current.Store(result.cfg)
+	loaded = result
+	apply(result.cfg)
 }
 
-func initEnvironment() {
-	config := viper.New()
-	config.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-
-	config.SetDefault("read.timeout", 10)
-	config.SetDefault("write.timeout", 10)
-	config.SetDefault("max.header.bytes", 1<<20)
-
-	config.SetDefault("log.level", "info")
-	config.SetDefault("log.dir", "./logs")
-
-	config.SetDefault("postgres.sslmode", "disable")
-
-	config.AutomaticEnv()
-
-	ReadTimeout = time.Duration(config.GetInt("read.timeout")) * time.Second
-	WriteTimeout = time.Duration(config.GetInt("write.timeout")) * time.Second
-	MaxHeaderBytes = config.GetInt("max.header.bytes")
-
-	LogLevel = config.GetString("log.level")
-	LogDirPath = config.GetString("log.dir")
-
-	Oauth2GithubClientID = config.GetString("oauth2.github.client.id")
-	Oauth2GithubClientSecret = config.GetString("oauth2.github.client.secret")
-	Oauth2StateString = config.GetString("oauth2.state.string")
-	Oauth2GithubRedirectURL = config.GetString("oauth2.github.redirect.url")
-
-	Oauth2GoogleClientID = config.GetString("oauth2.google.client.id")
-	Oauth2GoogleClientSecret = config.GetString("oauth2.google.client.secret")
-	Oauth2GoogleRedirectURL = config.GetString("oauth2.google.redirect.url")
-
-	PostgresUser = config.GetString("postgres.user")
-	PostgresPassword = config.GetString("postgres.password")
-	PostgresHost = config.GetString("postgres.host")
-	PostgresPort = config.GetString("postgres.port")
-	PostgresDatabase = config.GetString("postgres.database")
-	PostgresSSLMode = config.GetString("postgres.sslmode")
-
-	RedisHost = config.GetString("redis.host")
-	RedisPort = config.GetString("redis.port")
-	RedisPassword = config.GetString("redis.password")
-
-	MinioEndpoint = config.GetString("minio.endpoint")
-	MinioTLS = config.GetBool("minio.tls")
-	MinioRegion = config.GetString("minio.region")
-	MinioBucketName = config.GetString("minio.bucket.name")
-	MinioAccessID = config.GetString("minio.access.id")
-	MinioAccessKey = config.GetString("minio.access.key")
-
-	CosBucketName = config.GetString("cos.bucket.name")
-	CosAppID = config.GetString("cos.app.id")
-	CosRegion = config.GetString("cos.region")
-	CosSecretID = config.GetString("cos.secret.id")
-	CosSecretKey = config.GetString("cos.secret.key")
-
-	OpenAIModel = config.GetString("openai.model")
-	OpenAIAPIKey = config.GetString("openai.api.key")
-	OpenAIBaseURL = config.GetString("openai.base.url")
-
-	JwtAccessTokenExpired = config.GetDuration("jwt.access.token.expired")
-	JwtAccessTokenSecret = config.GetString("jwt.access.token.secret")
-
-	JwtRefreshTokenExpired = config.GetDuration("jwt.refresh.token.expired")
-	JwtRefreshTokenSecret = config.GetString("jwt.refresh.token.secret")
+// apply 将启动时加载的配置写入包级变量，热更新不会修改包级变量
+func apply(cfg *Config) {
+	AppEnv = cfg.App.Env
+
+	ReadTimeout = cfg.Server.ReadTimeout
+	WriteTimeout = cfg.Server.WriteTimeout
+	ShutdownTimeout = cfg.Server.ShutdownTimeout
+	MaxHeaderBytes = cfg.Server.MaxHeaderBytes
+
+	LogDirPath = cfg.Log.Dir
+
+	Oauth2StateString = cfg.Oauth2.StateString
+	Oauth2GithubClientID = cfg.Oauth2.Github.ClientID
+	Oauth2GithubClientSecret = cfg.Oauth2.Github.ClientSecret
+	Oauth2GithubRedirectURL = cfg.Oauth2.Github.RedirectURL
+
+	Oauth2GoogleClientID = cfg.Oauth2.Google.ClientID
+	Oauth2GoogleClientSecret = cfg.Oauth2.Google.ClientSecret
+	Oauth2GoogleRedirectURL = cfg.Oauth2.Google.RedirectURL
+
+	Oauth2QQClientID = cfg.Oauth2.QQ.ClientID
+	Oauth2QQClientSecret = cfg.Oauth2.QQ.ClientSecret
+	Oauth2QQRedirectURL = cfg.Oauth2.QQ.RedirectURL
+
+	DatabaseDriver = cfg.Database.Driver
+	DatabaseTimeZone = cfg.Database.TimeZone
+	DatabaseMaxIdleConns = cfg.Database.MaxIdleConns
+	DatabaseMaxOpenConns = cfg.Database.MaxOpenConns
+	DatabaseConnMaxLifetime = cfg.Database.ConnMaxLifetime
+	DatabaseConnMaxIdleTime = cfg.Database.ConnMaxIdleTime
+	DatabaseReplicas = cfg.Database.Replicas
+	DatabaseReplicaMaxLag = cfg.Database.ReplicaMaxLag
+	DatabaseReplicaCheckInterval = cfg.Database.ReplicaCheckInterval
+	DatabaseReplicaStickyWindow = cfg.Database.ReplicaStickyWindow
+
+	PostgresUser = cfg.Postgres.User
+	PostgresPassword = cfg.Postgres.Password
+	PostgresHost = cfg.Postgres.Host
+	PostgresPort = cfg.Postgres.Port
+	PostgresDatabase = cfg.Postgres.Database
+	PostgresSSLMode = cfg.Postgres.SSLMode
+	PostgresTenantRLS = cfg.Postgres.TenantRLS
+
+	MysqlUser = cfg.Mysql.User
+	MysqlPassword = cfg.Mysql.Password
+	MysqlHost = cfg.Mysql.Host
+	MysqlPort = cfg.Mysql.Port
+	MysqlDatabase = cfg.Mysql.Database
+
+	SqlitePath = cfg.Sqlite.Path
+
+	BackupCron = cfg.Backup.Cron
+	BackupRetentionCount = cfg.Backup.RetentionCount
+	BackupRetentionDays = cfg.Backup.RetentionDays
+
+	RedisHost = cfg.Redis.Host
+	RedisPort = cfg.Redis.Port
+	RedisPassword = cfg.Redis.Password
+	RedisMode = cfg.Redis.Mode
+	RedisAddrs = cfg.Redis.Addrs
+	RedisUsername = cfg.Redis.Username
+	RedisDB = cfg.Redis.DB
+	RedisSentinelMaster = cfg.Redis.SentinelMaster
+	RedisSentinelUsername = cfg.Redis.SentinelUsername
+	RedisSentinelPassword = cfg.Redis.SentinelPassword
+	RedisTLS = cfg.Redis.TLS
+	RedisTLSServerName = cfg.Redis.TLSServerName
+	RedisTLSCAFile = cfg.Redis.TLSCAFile
+	RedisTLSCertFile = cfg.Redis.TLSCertFile
+	RedisTLSKeyFile = cfg.Redis.TLSKeyFile
+	RedisTLSInsecureSkipVerify = cfg.Redis.TLSInsecureSkipVerify
+	RedisKeyPrefix = cfg.Redis.KeyPrefix
+
+	CacheDAOTTL = cfg.Cache.DAOTTL
+	CacheDAONegativeTTL = cfg.Cache.DAONegativeTTL
+	CacheLocalSize = cfg.Cache.LocalSize
+	CacheLocalTTL = cfg.Cache.LocalTTL
+	CacheNamespaceTTLs = cfg.Cache.NamespaceTTLs
+
+	IdempotencyTTL = cfg.Idempotency.TTL
+
+	DefaultLocale = cfg.I18n.DefaultLocale
+
+	OtelServiceName = cfg.Otel.ServiceName
+	OtelExporterOTLPEndpoint = cfg.Otel.ExporterOTLPEndpoint
+	OtelExporterOTLPHeaders = cfg.Otel.ExporterOTLPHeaders
+	OtelTracesSamplerArg = cfg.Otel.TracesSamplerArg
+
+	HealthCheckTimeout = cfg.Health.CheckTimeout
+	HealthCacheTTL = cfg.Health.CacheTTL
+	HealthOptionalChecks = cfg.Health.OptionalChecks
+	HealthDrainDelay = cfg.Health.DrainDelay
+
+	MetricsEnabled = cfg.Metrics.Enabled
+	MetricsPort = cfg.Metrics.Port
+
+	MinioEndpoint = cfg.Minio.Endpoint
+	MinioTLS = cfg.Minio.TLS
+	MinioRegion = cfg.Minio.Region
+	MinioBucketName = cfg.Minio.BucketName
+	MinioAccessID = cfg.Minio.AccessID
+	MinioAccessKey = cfg.Minio.AccessKey
+
+	CosBucketName = cfg.Cos.BucketName
+	CosAppID = cfg.Cos.AppID
+	CosRegion = cfg.Cos.Region
+	CosSecretID = cfg.Cos.SecretID
+	CosSecretKey = cfg.Cos.SecretKey
+
+	OpenAIModel = cfg.OpenAI.Model
+	OpenAIAPIKey = cfg.OpenAI.APIKey
+	OpenAIBaseURL = cfg.OpenAI.BaseURL
+
+	JwtAccessTokenExpired = cfg.Jwt.AccessTokenExpired
+	JwtAccessTokenSecret = cfg.Jwt.AccessTokenSecret
+
+	JwtRefreshTokenExpired = cfg.Jwt.RefreshTokenExpired
+	JwtRefreshTokenSecret = cfg.Jwt.RefreshTokenSecret
 }
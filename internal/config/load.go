@@ -0,0 +1,469 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/viper"
+)
+
+// 配置项的来源
+const (
+	// SourceDefault 默认值
+	SourceDefault = "default"
+	// SourceFile 配置文件或 profile 配置文件
+	SourceFile = "file"
+	// SourceEnv 环境变量
+	SourceEnv = "env"
+	// SourceEnvFile 环境变量 XXX_FILE 指向的文件，用于 Docker secrets
+	SourceEnvFile = "env_file"
+)
+
+// configFileEnv 指定配置文件路径的环境变量，支持 .yaml / .yml / .toml
+const configFileEnv = "CONFIG_FILE"
+
+// fileEnvSuffix 从文件读取配置值的环境变量后缀，如 JWT_ACCESS_TOKEN_SECRET_FILE=/run/secrets/jwt
+const fileEnvSuffix = "_FILE"
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	ratesType    = reflect.TypeOf(Rates{})
+)
+
+// Field 配置项，由 Config 的结构体标签生成
+//
+//	author centonhuang
+//	update 2026-10-18 19:56:02
+type Field struct {
+	// Key 配置键，如 database.max_idle_conns
+	Key string
+	// Env 对应的环境变量，如 DATABASE_MAX_IDLE_CONNS
+	Env     string
+	Default string
+	// Example 生成配置模板时的示例值，为空时使用默认值
+	Example string
+	// Doc 生成配置模板时写在配置项上方的说明
+	Doc    string
+	Secret bool
+	Reload bool
+
+	value reflect.Value
+}
+
+// Value 配置项在所属 Config 中的当前值
+//
+//	receiver f Field
+//	return any
+//	author centonhuang
+//	update 2026-10-18 18:23:08
+func (f Field) Value() any {
+	return f.value.Interface()
+}
+
+// Format 以环境变量的格式输出配置项的当前值，secret 标记的配置项已设置时脱敏
+//
+//	receiver f Field
+//	return string
+//	author centonhuang
+//	update 2026-10-18 19:06:02
+func (f Field) Format() string {
+	value := format(f.value)
+	if f.Secret && value != "" {
+		return redacted
+	}
+	return value
+}
+
+// Source 启动时配置项的来源，取值为 SourceDefault / SourceFile / SourceEnv / SourceEnvFile，无法读取或解析时为空
+//
+//	param key string
+//	return string
+//	author centonhuang
+//	update 2026-10-18 19:06:08
+func Source(key string) string {
+	return loaded.sources[key]
+}
+
+// Files 启动时读取的配置文件，包括 profile 配置文件
+//
+//	return []string
+//	author centonhuang
+//	update 2026-10-18 19:06:14
+func Files() []string {
+	return loaded.files
+}
+
+// Fields 按定义顺序列出 cfg 的所有配置项
+//
+//	param cfg *Config
+//	return []Field
+//	author centonhuang
+//	update 2026-10-18 18:23:14
+func Fields(cfg *Config) []Field {
+	return collectFields(reflect.ValueOf(cfg).Elem(), "")
+}
+
+func collectFields(v reflect.Value, prefix string) []Field {
+	var fields []Field
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		key, ok := sf.Tag.Lookup("key")
+		if !ok {
+			continue
+		}
+		if prefix != "" && key != "" {
+			key = prefix + "." + key
+		} else if key == "" {
+			key = prefix
+		}
+
+		if sf.Type.Kind() == reflect.Struct && sf.Type != durationType {
+			fields = append(fields, collectFields(v.Field(i), key)...)
+			continue
+		}
+		fields = append(fields, Field{
+			Key:     key,
+			Env:     envName(key),
+			Default: sf.Tag.Get("default"),
+			Example: sf.Tag.Get("example"),
+			Doc:     sf.Tag.Get("doc"),
+			Secret:  sf.Tag.Get("secret") == "true",
+			Reload:  sf.Tag.Get("reload") == "true",
+			value:   v.Field(i),
+		})
+	}
+	return fields
+}
+
+// envName 配置键对应的环境变量名
+func envName(key string) string {
+	return strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// redacted 脱敏后的敏感配置
+const redacted = "******"
+
+// format 将配置值转换为可被 decode 解析的字符串，映射按键排序
+func format(v reflect.Value) string {
+	switch {
+	case v.Type() == durationType:
+		return formatDuration(time.Duration(v.Int()))
+	case v.Type() == ratesType:
+		windows := lo.Keys(v.Interface().(Rates))
+		slices.Sort(windows)
+		items := lo.Map(windows, func(d time.Duration, _ int) string {
+			return fmt.Sprintf("%s=%d", formatDuration(d), v.Interface().(Rates)[d])
+		})
+		return strings.Join(items, ",")
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		return strings.Join(v.Interface().([]string), ",")
+	case reflect.Map:
+		keys := lo.Map(v.MapKeys(), func(k reflect.Value, _ int) string { return k.String() })
+		slices.Sort(keys)
+		items := lo.Map(keys, func(k string, _ int) string {
+			return k + "=" + format(v.MapIndex(reflect.ValueOf(k)))
+		})
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// formatDuration 去掉 Duration.String 末尾多余的零值单位，如 24h0m0s -> 24h
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// loadResult 一次加载的结果
+type loadResult struct {
+	cfg     *Config
+	sources map[string]string
+	files   []string
+	viper   *viper.Viper
+}
+
+// load 按 默认值 < 配置文件 < profile 配置文件 < 环境变量 < XXX_FILE 的优先级加载配置
+//
+//	配置文件由 CONFIG_FILE 指定，profile 配置文件为同目录下的 <name>.<app.env>.<ext>，存在时合并；
+//	配置文件无法读取或配置项无法解析时记录为问题并继续加载，由 Validate 统一报告
+func load() *loadResult {
+	v := viper.New()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	cfg := &Config{invalid: map[string]bool{}}
+	fields := Fields(cfg)
+	for _, field := range fields {
+		v.SetDefault(field.Key, field.Default)
+	}
+
+	result := &loadResult{cfg: cfg, sources: map[string]string{}, viper: v}
+	if path := os.Getenv(configFileEnv); path != "" {
+		if err := readConfigFiles(v, path, &result.files); err != nil {
+			cfg.problems = append(cfg.problems, fmt.Sprintf("%s: %v", configFileEnv, err))
+		}
+	}
+
+	for _, field := range fields {
+		raw, source, err := lookup(v, field)
+		if err != nil {
+			cfg.problems = append(cfg.problems, err.Error())
+			cfg.invalid[field.Key] = true
+			continue
+		}
+
+		value, err := decode(raw, field.value.Type())
+		if err != nil {
+			cfg.problems = append(cfg.problems, fmt.Sprintf("%s (%s): %v", field.Key, field.Env, err))
+			cfg.invalid[field.Key] = true
+			continue
+		}
+		field.value.Set(value)
+		result.sources[field.Key] = source
+	}
+	cfg.normalize()
+	return result
+}
+
+// readConfigFiles 读取基础配置文件并合并 profile 配置文件，结束后 v 指向基础配置文件以便监听
+func readConfigFiles(v *viper.Viper, path string, files *[]string) error {
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("read config file %s: %w", path, err)
+	}
+	*files = append(*files, path)
+
+	profile := profilePath(path, v.GetString("app.env"))
+	if profile == "" {
+		return nil
+	}
+	if _, err := os.Stat(profile); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("stat profile config file %s: %w", profile, err)
+	}
+
+	v.SetConfigFile(profile)
+	defer v.SetConfigFile(path)
+	if err := v.MergeInConfig(); err != nil {
+		return fmt.Errorf("read profile config file %s: %w", profile, err)
+	}
+	*files = append(*files, profile)
+	return nil
+}
+
+// profilePath 基础配置文件对应的 profile 配置文件路径，如 config.yaml -> config.production.yaml
+func profilePath(path, env string) string {
+	if env == "" {
+		return ""
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + env + ext
+}
+
+// lookup 取配置项的原始值与来源，XXX_FILE 优先于 XXX，两者同时设置时报错
+func lookup(v *viper.Viper, field Field) (raw any, source string, err error) {
+	if file := os.Getenv(field.Env + fileEnvSuffix); file != "" {
+		if os.Getenv(field.Env) != "" {
+			return nil, "", fmt.Errorf("%s and %s%s are both set", field.Env, field.Env, fileEnvSuffix)
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s%s: %w", field.Env, fileEnvSuffix, err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), SourceEnvFile, nil
+	}
+
+	switch {
+	case os.Getenv(field.Env) != "":
+		source = SourceEnv
+	case v.InConfig(field.Key):
+		source = SourceFile
+	default:
+		source = SourceDefault
+	}
+	return v.Get(field.Key), source, nil
+}
+
+// normalize 统一大小写等不影响含义的格式差异
+func (c *Config) normalize() {
+	c.Database.Driver = strings.ToLower(c.Database.Driver)
+	c.Redis.Mode = strings.ToLower(c.Redis.Mode)
+}
+
+// decode 将环境变量中的字符串或配置文件中的原生值转换为字段类型
+//
+//	时长可写作秒数或 Go 时长格式；列表与映射可写作逗号分隔的字符串，也可在配置文件中写作原生的列表与映射
+func decode(raw any, t reflect.Type) (reflect.Value, error) {
+	switch {
+	case t == durationType:
+		d, err := parseDuration(scalar(raw))
+		return reflect.ValueOf(d), err
+	case t == ratesType:
+		items, err := pairs(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		rates, err := parseRates(items)
+		return reflect.ValueOf(rates), err
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(scalar(raw)).Convert(t), nil
+	case reflect.Bool:
+		s := scalar(raw)
+		if s == "" {
+			return reflect.ValueOf(false), nil
+		}
+		b, err := strconv.ParseBool(s)
+		return reflect.ValueOf(b), err
+	case reflect.Int:
+		s := scalar(raw)
+		if s == "" {
+			return reflect.ValueOf(0), nil
+		}
+		n, err := strconv.Atoi(s)
+		return reflect.ValueOf(n), err
+	case reflect.Float64:
+		s := scalar(raw)
+		if s == "" {
+			return reflect.ValueOf(0.0), nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		return reflect.ValueOf(f), err
+	case reflect.Slice:
+		return reflect.ValueOf(list(raw)), nil
+	case reflect.Map:
+		items, err := pairs(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if t.Elem() == durationType {
+			durations, err := parseSecondsMap(items)
+			return reflect.ValueOf(durations), err
+		}
+		return reflect.ValueOf(items), nil
+	}
+	return reflect.Value{}, fmt.Errorf("unsupported type %s", t)
+}
+
+// scalar 将原生值转换为去除首尾空白的字符串
+func scalar(raw any) string {
+	if raw == nil {
+		return ""
+	}
+	if s, ok := raw.(string); ok {
+		return strings.TrimSpace(s)
+	}
+	return strings.TrimSpace(fmt.Sprint(raw))
+}
+
+// list 解析逗号分隔的字符串或配置文件中的列表，忽略空白项
+func list(raw any) []string {
+	if items, ok := raw.([]any); ok {
+		var values []string
+		for _, item := range items {
+			if s := scalar(item); s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return splitList(scalar(raw))
+}
+
+// pairs 解析 key=value 形式的逗号分隔字符串或配置文件中的映射
+func pairs(raw any) (map[string]string, error) {
+	if m, ok := raw.(map[string]any); ok {
+		values := make(map[string]string, len(m))
+		for k, v := range m {
+			values[k] = scalar(v)
+		}
+		return values, nil
+	}
+	return parseStringMap(scalar(raw))
+}
+
+// splitList 解析逗号分隔的配置项，忽略空白项
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseStringMap 解析 key=value 形式的逗号分隔配置项
+func parseStringMap(value string) (map[string]string, error) {
+	pairs := map[string]string{}
+	for _, item := range splitList(value) {
+		key, val, ok := strings.Cut(item, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid item %q, expected key=value", item)
+		}
+		pairs[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	return pairs, nil
+}
+
+// parseDuration 解析秒数或 Go 时长格式，空值为 0
+func parseDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return time.ParseDuration(value)
+}
+
+// parseSecondsMap 解析 name=秒数 形式的映射
+func parseSecondsMap(items map[string]string) (map[string]time.Duration, error) {
+	durations := make(map[string]time.Duration, len(items))
+	for name, value := range items {
+		d, err := parseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q for %s", value, name)
+		}
+		durations[name] = d
+	}
+	return durations, nil
+}
+
+// parseRates 解析 窗口时长=次数 形式的映射，如 1s=10,24h=10000
+func parseRates(items map[string]string) (Rates, error) {
+	rates := make(Rates, len(items))
+	for period, limit := range items {
+		d, err := time.ParseDuration(period)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid window %q, expected a positive duration like 1s or 24h", period)
+		}
+		n, err := strconv.ParseInt(limit, 10, 64)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid limit %q for window %s, expected a positive integer", limit, period)
+		}
+		rates[d] = n
+	}
+	return rates, nil
+}
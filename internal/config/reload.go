@@ -0,0 +1,120 @@
+package config
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ReloadEvent 配置文件变化后的热更新结果
+//
+//	author centonhuang
+//	update 2026-10-18 18:26:02
+type ReloadEvent struct {
+	// Applied 已生效的配置键
+	Applied []string
+	// Ignored 已变化但需要重启才能生效的配置键
+	Ignored []string
+	// Err 新配置不合法，此时不更新任何配置
+	Err error
+}
+
+var (
+	// current 当前生效的配置，热更新时整体替换，读取方不得修改
+	current atomic.Pointer[Config]
+
+	// loaded 启动时的加载结果
+	loaded *loadResult
+
+	reloadMu    sync.Mutex
+	reloadHooks []func(event ReloadEvent)
+	watchOnce   sync.Once
+)
+
+// Current 当前生效的配置，reload 标记的配置项需通过它读取才能热更新
+//
+//	return *Config 只读
+//	author centonhuang
+//	update 2026-10-18 18:26:08
+func Current() *Config {
+	return current.Load()
+}
+
+// OnReload 注册热更新回调，配置文件变化并重新加载后调用，新配置不合法时同样调用
+//
+//	param hook func(event ReloadEvent)
+//	author centonhuang
+//	update 2026-10-18 18:26:14
+func OnReload(hook func(event ReloadEvent)) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	reloadHooks = append(reloadHooks, hook)
+}
+
+// WatchConfig 监听 CONFIG_FILE 指定的配置文件，变化时重新加载并热更新 reload 标记的配置项
+//
+//	profile 配置文件与环境变量的变化不会触发热更新
+//
+//	return bool 未使用配置文件时为 false
+//	author centonhuang
+//	update 2026-10-18 18:26:20
+func WatchConfig() bool {
+	if len(loaded.files) == 0 {
+		return false
+	}
+	watchOnce.Do(func() {
+		loaded.viper.OnConfigChange(func(fsnotify.Event) {
+			reload()
+		})
+		loaded.viper.WatchConfig()
+	})
+	return true
+}
+
+// reload 重新加载并校验配置，只替换 reload 标记的配置项，其余变化的配置项报告为需要重启
+func reload() {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	next := load().cfg
+	if err := Validate(next); err != nil {
+		notify(ReloadEvent{Err: err})
+		return
+	}
+
+	previous := Current()
+	merged := *previous
+	previousFields, nextFields, mergedFields := Fields(previous), Fields(next), Fields(&merged)
+
+	event := ReloadEvent{}
+	for i, field := range nextFields {
+		if reflect.DeepEqual(previousFields[i].Value(), field.Value()) {
+			continue
+		}
+		if !field.Reload {
+			event.Ignored = append(event.Ignored, field.Key)
+			continue
+		}
+		mergedFields[i].value.Set(field.value)
+		event.Applied = append(event.Applied, field.Key)
+	}
+
+	// 编辑器保存时可能触发多次文件事件，没有变化时不通知
+	if len(event.Applied) == 0 && len(event.Ignored) == 0 {
+		return
+	}
+	if len(event.Applied) > 0 {
+		current.Store(&merged)
+	}
+	notify(event)
+}
+
+// notify 调用热更新回调，调用方需持有 reloadMu
+func notify(event ReloadEvent) {
+	for _, hook := range reloadHooks {
+		hook(event)
+	}
+}
@@ -0,0 +1,347 @@
+package config
+
+import "time"
+
+// Config 应用配置
+//
+//	字段的 key 标签逐级拼接为配置键，如 database.max_idle_conns，对应配置文件中的嵌套键与环境变量 DATABASE_MAX_IDLE_CONNS；
+//	key 为空的分组直接展开到上一级。default 为默认值，example 为生成配置模板时使用的示例值，doc 为写入配置模板的说明，
+//	secret 标记的字段在输出时脱敏，reload 标记的字段在配置文件变化时热更新。
+//
+//	author centonhuang
+//	update 2026-10-18 19:56:08
+type Config struct {
+	App         AppConfig         `key:"app"`
+	Server      ServerConfig      `key:""`
+	Log         LogConfig         `key:"log"`
+	CORS        CORSConfig        `key:"cors"`
+	Oauth2      Oauth2Config      `key:"oauth2"`
+	Database    DatabaseConfig    `key:"database"`
+	Postgres    PostgresConfig    `key:"postgres"`
+	Mysql       MysqlConfig       `key:"mysql"`
+	Sqlite      SqliteConfig      `key:"sqlite"`
+	Backup      BackupConfig      `key:"backup"`
+	Redis       RedisConfig       `key:"redis"`
+	Cache       CacheConfig       `key:"cache"`
+	RateLimit   RateLimitConfig   `key:"rate_limit"`
+	Idempotency IdempotencyConfig `key:"idempotency"`
+	I18n        I18nConfig        `key:"i18n"`
+	Otel        OtelConfig        `key:"otel"`
+	Health      HealthConfig      `key:"health"`
+	Metrics     MetricsConfig     `key:"metrics"`
+	Minio       MinioConfig       `key:"minio"`
+	Cos         CosConfig         `key:"cos"`
+	OpenAI      OpenAIConfig      `key:"openai"`
+	Jwt         JwtConfig         `key:"jwt"`
+
+	// problems 加载时的问题，校验时一并报告
+	problems []string
+	// invalid 无法解析的配置键，校验时不再重复报告
+	invalid map[string]bool
+}
+
+// AppConfig 运行环境配置
+//
+//	author centonhuang
+//	update 2026-10-18 20:10:02
+type AppConfig struct {
+	// Env 运行环境，同时决定加载的配置文件 profile，仅 development/test/local 下允许直接写入种子数据等危险操作；
+	// 没有默认值，未设置时启动校验失败，避免未设置的生产环境被当作开发环境
+	Env string `key:"env" example:"development" doc:"必填，生产环境请设置为 production；仅 development/test/local 下允许直接执行 database seed/reset"`
+}
+
+// ServerConfig HTTP 服务配置，配置键位于顶层
+//
+//	author centonhuang
+//	update 2026-10-18 18:20:14
+type ServerConfig struct {
+	ReadTimeout     time.Duration `key:"read_timeout" default:"10"`
+	WriteTimeout    time.Duration `key:"write_timeout" default:"10"`
+	ShutdownTimeout time.Duration `key:"shutdown_timeout" default:"30"`
+	MaxHeaderBytes  int           `key:"max_header_bytes" default:"1048576"`
+}
+
+// LogConfig 日志配置
+//
+//	author centonhuang
+//	update 2026-10-18 18:20:20
+type LogConfig struct {
+	Level string `key:"level" default:"info" reload:"true"`
+	Dir   string `key:"dir" default:"./logs"`
+}
+
+// CORSConfig 跨域配置
+//
+//	author centonhuang
+//	update 2026-10-18 18:20:26
+type CORSConfig struct {
+	// AllowOrigins 允许跨域携带凭证访问的来源，需精确匹配 scheme://host[:port]，不支持通配符
+	AllowOrigins []string `key:"allow_origins" default:"http://localhost:3000" reload:"true"`
+}
+
+// Oauth2Config OAuth2 登录配置
+//
+//	author centonhuang
+//	update 2026-10-18 18:20:32
+type Oauth2Config struct {
+	StateString string             `key:"state_string" secret:"true"`
+	Github      Oauth2ClientConfig `key:"github"`
+	Google      Oauth2ClientConfig `key:"google"`
+	QQ          Oauth2ClientConfig `key:"qq"`
+}
+
+// Oauth2ClientConfig 单个 OAuth2 提供商的客户端配置，ClientID 为空表示未启用
+//
+//	author centonhuang
+//	update 2026-10-18 18:20:38
+type Oauth2ClientConfig struct {
+	ClientID     string `key:"client_id"`
+	ClientSecret string `key:"client_secret" secret:"true"`
+	RedirectURL  string `key:"redirect_url"`
+}
+
+// DatabaseConfig 数据库连接池与副本配置
+//
+//	author centonhuang
+//	update 2026-10-18 19:44:02
+type DatabaseConfig struct {
+	// Driver 数据库驱动，可选 postgres / mysql / sqlite
+	Driver          string        `key:"driver" default:"postgres"`
+	TimeZone        string        `key:"timezone" default:"Asia/Shanghai"`
+	MaxIdleConns    int           `key:"max_idle_conns" default:"10"`
+	MaxOpenConns    int           `key:"max_open_conns" default:"100"`
+	ConnMaxLifetime time.Duration `key:"conn_max_lifetime" default:"18000"`
+	ConnMaxIdleTime time.Duration `key:"conn_max_idle_time" default:"0"`
+	// Replicas 只读副本地址列表(host:port)，副本与主库使用相同的账号和库名
+	Replicas             []string      `key:"replicas"`
+	ReplicaMaxLag        time.Duration `key:"replica_max_lag" default:"5"`
+	ReplicaCheckInterval time.Duration `key:"replica_check_interval" default:"10"`
+	// ReplicaStickyWindow 用户写请求成功后，其读请求在该时长内走主库以读到自己的写入，0 表示关闭
+	ReplicaStickyWindow time.Duration `key:"replica_sticky_window" default:"5"`
+}
+
+// PostgresConfig Postgres 连接配置
+//
+//	author centonhuang
+//	update 2026-10-18 19:05:50
+type PostgresConfig struct {
+	User     string `key:"user" example:"hcd233"`
+	Password string `key:"password" secret:"true"`
+	Host     string `key:"host" example:"localhost"`
+	Port     string `key:"port" example:"5432"`
+	Database string `key:"database" example:"tmpl-db"`
+	SSLMode  string `key:"sslmode" default:"disable"`
+	// TenantRLS 是否启用行级安全策略做租户隔离
+	TenantRLS bool `key:"tenant_rls" default:"false"`
+}
+
+// MysqlConfig MySQL 连接配置
+//
+//	author centonhuang
+//	update 2026-10-18 19:05:56
+type MysqlConfig struct {
+	User     string `key:"user" example:"hcd233"`
+	Password string `key:"password" secret:"true"`
+	Host     string `key:"host" example:"localhost"`
+	Port     string `key:"port" default:"3306"`
+	Database string `key:"database" example:"tmpl-db"`
+}
+
+// SqliteConfig SQLite 配置
+//
+//	author centonhuang
+//	update 2026-10-18 18:21:02
+type SqliteConfig struct {
+	// Path 数据库文件路径，:memory: 表示内存数据库
+	Path string `key:"path" default:"./data/sqlite.db"`
+}
+
+// BackupConfig 数据库备份配置
+//
+//	author centonhuang
+//	update 2026-10-18 18:21:08
+type BackupConfig struct {
+	// Cron 定时备份的 cron 表达式，为空表示不开启
+	Cron           string `key:"cron"`
+	RetentionCount int    `key:"retention_count" default:"7"`
+	RetentionDays  int    `key:"retention_days" default:"30"`
+}
+
+// RedisConfig Redis 连接配置
+//
+//	author centonhuang
+//	update 2026-10-18 19:05:14
+type RedisConfig struct {
+	Host     string `key:"host" example:"localhost"`
+	Port     string `key:"port" example:"6379"`
+	Password string `key:"password" secret:"true"`
+	// Mode 部署模式，可选 single / sentinel / cluster
+	Mode string `key:"mode" default:"single"`
+	// Addrs 哨兵或集群节点地址列表，为空时使用 Host:Port
+	Addrs                 []string `key:"addrs"`
+	Username              string   `key:"username"`
+	DB                    int      `key:"db" default:"0"`
+	SentinelMaster        string   `key:"sentinel_master"`
+	SentinelUsername      string   `key:"sentinel_username"`
+	SentinelPassword      string   `key:"sentinel_password" secret:"true"`
+	TLS                   bool     `key:"tls" default:"false"`
+	TLSServerName         string   `key:"tls_server_name"`
+	TLSCAFile             string   `key:"tls_ca_file"`
+	TLSCertFile           string   `key:"tls_cert_file"`
+	TLSKeyFile            string   `key:"tls_key_file"`
+	TLSInsecureSkipVerify bool     `key:"tls_insecure_skip_verify" default:"false"`
+	// KeyPrefix 所有键与频道的前缀，用于多个环境共用同一个 Redis，如 staging:
+	KeyPrefix string `key:"key_prefix"`
+}
+
+// CacheConfig 缓存配置，时长不为正时关闭对应的缓存
+//
+//	author centonhuang
+//	update 2026-10-18 18:21:20
+type CacheConfig struct {
+	DAOTTL         time.Duration `key:"dao_ttl" default:"300"`
+	DAONegativeTTL time.Duration `key:"dao_negative_ttl" default:"30"`
+	LocalSize      int           `key:"local_size" default:"10000"`
+	LocalTTL       time.Duration `key:"local_ttl" default:"30"`
+	// NamespaceTTLs 按命名空间覆盖缓存时间，格式为 name=秒数,name=秒数
+	NamespaceTTLs map[string]time.Duration `key:"namespace_ttls"`
+}
+
+// Rates 窗口时长 -> 次数，格式为 1s=10,24h=10000
+//
+//	update 2026-10-18 18:21:26
+type Rates map[time.Duration]int64
+
+// RateLimitConfig 按权限划分的限频窗口与长期配额，未配置的权限不限频
+//
+//	author centonhuang
+//	update 2026-10-18 18:21:32
+type RateLimitConfig struct {
+	Reader       Rates `key:"reader" default:"1s=10,24h=10000" reload:"true"`
+	Creator      Rates `key:"creator" default:"1s=20,24h=50000" reload:"true"`
+	Admin        Rates `key:"admin" default:"1s=50" reload:"true"`
+	QuotaReader  Rates `key:"quota_reader" default:"720h=100000" reload:"true"`
+	QuotaCreator Rates `key:"quota_creator" default:"720h=1000000" reload:"true"`
+	QuotaAdmin   Rates `key:"quota_admin" reload:"true"`
+}
+
+// Tier 权限对应的限频窗口与长期配额
+//
+//	receiver c RateLimitConfig
+//	param permission string
+//	return windows Rates
+//	return quotas Rates
+//	author centonhuang
+//	update 2026-10-18 18:21:38
+func (c RateLimitConfig) Tier(permission string) (windows, quotas Rates) {
+	switch permission {
+	case "reader":
+		return c.Reader, c.QuotaReader
+	case "creator":
+		return c.Creator, c.QuotaCreator
+	case "admin":
+		return c.Admin, c.QuotaAdmin
+	default:
+		return nil, nil
+	}
+}
+
+// IdempotencyConfig 幂等键配置
+//
+//	author centonhuang
+//	update 2026-10-18 18:21:44
+type IdempotencyConfig struct {
+	TTL time.Duration `key:"ttl" default:"86400"`
+}
+
+// I18nConfig 国际化配置
+//
+//	author centonhuang
+//	update 2026-10-18 18:21:50
+type I18nConfig struct {
+	// DefaultLocale 用户未设置语言且请求未携带 Accept-Language 时使用的语言
+	DefaultLocale string `key:"default_locale" default:"en"`
+}
+
+// OtelConfig 链路追踪配置
+//
+//	author centonhuang
+//	update 2026-10-18 18:21:56
+type OtelConfig struct {
+	ServiceName string `key:"service_name" default:"go-backend-tmpl"`
+	// ExporterOTLPEndpoint OTLP HTTP 上报地址，如 http://localhost:4318，为空时不上报链路
+	ExporterOTLPEndpoint string `key:"exporter_otlp_endpoint"`
+	// ExporterOTLPHeaders 上报时附加的请求头，格式为 key=value,key=value
+	ExporterOTLPHeaders map[string]string `key:"exporter_otlp_headers" secret:"true"`
+	// TracesSamplerArg 未携带上游采样决定的请求的采样比例，0-1
+	TracesSamplerArg float64 `key:"traces_sampler_arg" default:"1.0"`
+}
+
+// HealthConfig 就绪检查配置
+//
+//	author centonhuang
+//	update 2026-10-18 20:04:02
+type HealthConfig struct {
+	CheckTimeout time.Duration `key:"check_timeout" default:"3"`
+	CacheTTL     time.Duration `key:"cache_ttl" default:"2"`
+	// OptionalChecks 非关键依赖，不可用时就绪检查返回降级但仍可接收流量
+	OptionalChecks []string `key:"optional_checks" default:"llm"`
+	// DrainDelay 停止时标记为未就绪后等待的时间，让负载均衡摘除实例后再停止接收请求
+	DrainDelay time.Duration `key:"drain_delay" default:"5" doc:"退出时 /readyz 先返回未就绪，等待该时长后再停止服务，应大于负载均衡就绪探针的间隔，且计入 SHUTDOWN_TIMEOUT"`
+}
+
+// MetricsConfig 指标配置
+//
+//	author centonhuang
+//	update 2026-10-18 19:56:14
+type MetricsConfig struct {
+	Enabled bool `key:"enabled" default:"true"`
+	// Port 指标服务的独立管理端口，为空时 /metrics 挂载在 API 端口上
+	Port string `key:"port" default:"9091" doc:"/metrics 默认位于独立的管理端口，该端口不应对外开放；置空时挂载在 API 端口上且不鉴权，仅在 API 端口不对外开放时使用"`
+}
+
+// MinioConfig Minio 配置，Endpoint 为空表示未启用
+//
+//	author centonhuang
+//	update 2026-10-18 19:05:14
+type MinioConfig struct {
+	Endpoint   string `key:"endpoint" example:"minio:9000"`
+	TLS        bool   `key:"tls" default:"false"`
+	Region     string `key:"region"`
+	BucketName string `key:"bucket_name"`
+	AccessID   string `key:"access_id"`
+	AccessKey  string `key:"access_key" secret:"true"`
+}
+
+// CosConfig 腾讯云 COS 配置，AppID 为空表示未启用，同时配置时优先于 Minio
+//
+//	author centonhuang
+//	update 2026-10-18 18:22:20
+type CosConfig struct {
+	Region     string `key:"region"`
+	SecretID   string `key:"secret_id"`
+	SecretKey  string `key:"secret_key" secret:"true"`
+	BucketName string `key:"bucket_name"`
+	AppID      string `key:"app_id"`
+}
+
+// OpenAIConfig OpenAI 配置
+//
+//	author centonhuang
+//	update 2026-10-18 18:22:26
+type OpenAIConfig struct {
+	Model   string `key:"model"`
+	APIKey  string `key:"api_key" secret:"true"`
+	BaseURL string `key:"base_url"`
+}
+
+// JwtConfig JWT 配置，过期时间可写作秒数或 12h 形式
+//
+//	author centonhuang
+//	update 2026-10-18 19:05:32
+type JwtConfig struct {
+	AccessTokenExpired  time.Duration `key:"access_token_expired" example:"12h"`
+	AccessTokenSecret   string        `key:"access_token_secret" secret:"true"`
+	RefreshTokenExpired time.Duration `key:"refresh_token_expired" example:"168h"`
+	RefreshTokenSecret  string        `key:"refresh_token_secret" secret:"true"`
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// templateHeader 生成的模板文件头部说明
+var templateHeader = []string{
+	"由 go run main.go config template 根据 internal/config/schema.go 生成，请勿手动修改",
+	"优先级: 默认值 < CONFIG_FILE 配置文件 < profile 配置文件 < 环境变量 < <变量名>_FILE",
+}
+
+// EnvTemplate 根据配置定义生成环境变量模板，值为示例值或默认值，配置项按分组以空行分隔，说明写在配置项上方
+//
+//	return []byte
+//	author centonhuang
+//	update 2026-10-18 19:56:20
+func EnvTemplate() []byte {
+	var b bytes.Buffer
+	for _, line := range templateHeader {
+		fmt.Fprintf(&b, "# %s\n", line)
+	}
+	fmt.Fprintf(&b, "\n%s=\n", configFileEnv)
+
+	group := ""
+	for i, field := range Fields(&Config{}) {
+		if g := fieldGroup(field.Key); i == 0 || g != group {
+			b.WriteString("\n")
+			group = g
+		}
+		if field.Doc != "" {
+			fmt.Fprintf(&b, "# %s\n", field.Doc)
+		}
+		fmt.Fprintf(&b, "%s=%s\n", field.Env, templateValue(field))
+	}
+	return b.Bytes()
+}
+
+// YAMLTemplate 根据配置定义生成 YAML 配置文件示例，每个配置项注释对应的环境变量，说明写在配置项上方
+//
+//	return []byte
+//	return error
+//	author centonhuang
+//	update 2026-10-18 19:56:26
+func YAMLTemplate() ([]byte, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	sections := map[string]*yaml.Node{"": root}
+
+	for _, field := range Fields(&Config{}) {
+		parts := strings.Split(field.Key, ".")
+		parent := root
+		for i := range parts[:len(parts)-1] {
+			path := strings.Join(parts[:i+1], ".")
+			section, ok := sections[path]
+			if !ok {
+				section = &yaml.Node{Kind: yaml.MappingNode}
+				parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: parts[i]}, section)
+				sections[path] = section
+			}
+			parent = section
+		}
+
+		value, err := yamlValue(field)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Key, err)
+		}
+		// 流式的列表与映射需要将注释放在值上，否则会被输出到下一个键之后
+		value.LineComment = fieldComment(field)
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: parts[len(parts)-1], HeadComment: field.Doc}
+		parent.Content = append(parent.Content, key, value)
+	}
+
+	doc := &yaml.Node{Kind: yaml.DocumentNode, HeadComment: "# " + strings.Join(templateHeader, "\n# "), Content: []*yaml.Node{root}}
+
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// fieldGroup 配置项所属的分组，如 oauth2.github.client_id -> oauth2.github
+func fieldGroup(key string) string {
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		return key[:i]
+	}
+	return ""
+}
+
+// fieldComment YAML 示例中配置项的注释
+func fieldComment(field Field) string {
+	comment := field.Env
+	if field.Secret {
+		comment += ", secret"
+	}
+	if field.Reload {
+		comment += ", reload"
+	}
+	return comment
+}
+
+// templateValue 模板中的值，优先使用示例值
+func templateValue(field Field) string {
+	if field.Example != "" {
+		return field.Example
+	}
+	return field.Default
+}
+
+// yamlValue 将模板中的值转换为 YAML 原生类型，列表与映射使用流式写法
+func yamlValue(field Field) (*yaml.Node, error) {
+	value := templateValue(field)
+	t := field.value.Type()
+
+	if t == ratesType || t.Kind() == reflect.Map {
+		items, err := parseStringMap(value)
+		if err != nil {
+			return nil, err
+		}
+		node := &yaml.Node{Kind: yaml.MappingNode, Style: yaml.FlowStyle}
+		for _, item := range splitList(value) {
+			k, _, _ := strings.Cut(item, "=")
+			k = strings.TrimSpace(k)
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: k},
+				&yaml.Node{Kind: yaml.ScalarNode, Value: items[k]},
+			)
+		}
+		return node, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for _, item := range splitList(value) {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: item})
+		}
+		return node, nil
+	case reflect.String:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	}
+
+	// 数值、布尔值与时长未设置时留空，由 decode 按零值处理
+	if value == "" {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: ""}, nil
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: value}, nil
+}
@@ -0,0 +1,221 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ValidationError 配置校验失败，包含所有问题而不是只报告第一个
+//
+//	author centonhuang
+//	update 2026-10-18 18:24:02
+type ValidationError struct {
+	Problems []string
+}
+
+// Error 每行一个问题
+//
+//	receiver e *ValidationError
+//	return string
+//	author centonhuang
+//	update 2026-10-18 18:24:08
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid config, %d problem(s):", len(e.Problems))
+	for _, problem := range e.Problems {
+		b.WriteString("\n  - ")
+		b.WriteString(problem)
+	}
+	return b.String()
+}
+
+var (
+	logLevels      = []string{"debug", "info", "warn", "error", "dpanic", "panic", "fatal"}
+	databaseDriver = []string{"postgres", "mysql", "sqlite"}
+	redisModes     = []string{"single", "sentinel", "cluster"}
+)
+
+// Validate 校验配置，返回 *ValidationError 列出加载时无法解析的配置项与所有不合法的配置
+//
+//	对象存储、OAuth2 等可选依赖只在启用时校验
+//
+//	param cfg *Config
+//	return error
+//	author centonhuang
+//	update 2026-10-18 20:04:08
+func Validate(cfg *Config) error {
+	v := &validator{problems: append([]string(nil), cfg.problems...), invalid: cfg.invalid}
+
+	v.require("app.env", cfg.App.Env)
+
+	v.positive("read_timeout", int64(cfg.Server.ReadTimeout))
+	v.positive("write_timeout", int64(cfg.Server.WriteTimeout))
+	v.positive("shutdown_timeout", int64(cfg.Server.ShutdownTimeout))
+	v.positive("max_header_bytes", int64(cfg.Server.MaxHeaderBytes))
+
+	v.oneOf("log.level", strings.ToLower(cfg.Log.Level), logLevels)
+	v.require("log.dir", cfg.Log.Dir)
+
+	for _, origin := range cfg.CORS.AllowOrigins {
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" || strings.Contains(origin, "*") || (u.Path != "" && u.Path != "/") {
+			v.addf("cors.allow_origins", "invalid origin %q, expected scheme://host[:port] without wildcards", origin)
+		}
+	}
+
+	v.oauth2Client("oauth2.github", cfg.Oauth2.Github)
+	v.oauth2Client("oauth2.google", cfg.Oauth2.Google)
+	v.oauth2Client("oauth2.qq", cfg.Oauth2.QQ)
+	if cfg.Oauth2.Github.ClientID != "" || cfg.Oauth2.Google.ClientID != "" || cfg.Oauth2.QQ.ClientID != "" {
+		v.require("oauth2.state_string", cfg.Oauth2.StateString)
+	}
+
+	v.validateDatabase(cfg)
+	v.validateRedis(&cfg.Redis)
+
+	v.positive("backup.retention_count", int64(cfg.Backup.RetentionCount))
+	v.positive("backup.retention_days", int64(cfg.Backup.RetentionDays))
+	v.positive("idempotency.ttl", int64(cfg.Idempotency.TTL))
+	v.require("i18n.default_locale", cfg.I18n.DefaultLocale)
+
+	v.require("otel.service_name", cfg.Otel.ServiceName)
+	if cfg.Otel.TracesSamplerArg < 0 || cfg.Otel.TracesSamplerArg > 1 {
+		v.addf("otel.traces_sampler_arg", "must be between 0 and 1, got %v", cfg.Otel.TracesSamplerArg)
+	}
+
+	v.positive("health.check_timeout", int64(cfg.Health.CheckTimeout))
+	if cfg.Health.DrainDelay < 0 || cfg.Health.DrainDelay >= cfg.Server.ShutdownTimeout {
+		v.addf("health.drain_delay", "must be between 0 and shutdown_timeout %s, got %s", cfg.Server.ShutdownTimeout, cfg.Health.DrainDelay)
+	}
+	v.port("metrics.port", cfg.Metrics.Port)
+
+	if cfg.Minio.Endpoint != "" {
+		v.require("minio.bucket_name", cfg.Minio.BucketName)
+		v.require("minio.access_id", cfg.Minio.AccessID)
+		v.require("minio.access_key", cfg.Minio.AccessKey)
+	}
+	if cfg.Cos.AppID != "" {
+		v.require("cos.bucket_name", cfg.Cos.BucketName)
+		v.require("cos.region", cfg.Cos.Region)
+		v.require("cos.secret_id", cfg.Cos.SecretID)
+		v.require("cos.secret_key", cfg.Cos.SecretKey)
+	}
+
+	v.require("jwt.access_token_secret", cfg.Jwt.AccessTokenSecret)
+	v.require("jwt.refresh_token_secret", cfg.Jwt.RefreshTokenSecret)
+	v.positive("jwt.access_token_expired", int64(cfg.Jwt.AccessTokenExpired))
+	v.positive("jwt.refresh_token_expired", int64(cfg.Jwt.RefreshTokenExpired))
+	if cfg.Jwt.AccessTokenSecret != "" && cfg.Jwt.AccessTokenSecret == cfg.Jwt.RefreshTokenSecret {
+		v.addf("jwt.refresh_token_secret", "must differ from jwt.access_token_secret, otherwise refresh tokens are accepted as access tokens")
+	}
+
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+func (v *validator) validateDatabase(cfg *Config) {
+	v.oneOf("database.driver", cfg.Database.Driver, databaseDriver)
+	switch cfg.Database.Driver {
+	case "postgres":
+		v.require("postgres.host", cfg.Postgres.Host)
+		v.port("postgres.port", cfg.Postgres.Port)
+		v.require("postgres.user", cfg.Postgres.User)
+		v.require("postgres.database", cfg.Postgres.Database)
+	case "mysql":
+		v.require("mysql.host", cfg.Mysql.Host)
+		v.port("mysql.port", cfg.Mysql.Port)
+		v.require("mysql.user", cfg.Mysql.User)
+		v.require("mysql.database", cfg.Mysql.Database)
+	case "sqlite":
+		v.require("sqlite.path", cfg.Sqlite.Path)
+	}
+
+	v.positive("database.max_open_conns", int64(cfg.Database.MaxOpenConns))
+	if cfg.Database.MaxIdleConns > cfg.Database.MaxOpenConns {
+		v.addf("database.max_idle_conns", "must not exceed database.max_open_conns (%d)", cfg.Database.MaxOpenConns)
+	}
+	if len(cfg.Database.Replicas) > 0 {
+		v.positive("database.replica_check_interval", int64(cfg.Database.ReplicaCheckInterval))
+	}
+}
+
+func (v *validator) validateRedis(cfg *RedisConfig) {
+	if !v.oneOf("redis.mode", cfg.Mode, redisModes) {
+		return
+	}
+	switch cfg.Mode {
+	case "sentinel":
+		v.require("redis.sentinel_master", cfg.SentinelMaster)
+		if len(cfg.Addrs) == 0 {
+			v.addf("redis.addrs", "must list the sentinel addresses in sentinel mode")
+		}
+	case "cluster":
+		if len(cfg.Addrs) == 0 && cfg.Host == "" {
+			v.addf("redis.addrs", "must list the cluster node addresses in cluster mode")
+		}
+		if cfg.DB != 0 {
+			v.addf("redis.db", "must be 0 in cluster mode, got %d", cfg.DB)
+		}
+	}
+	if cfg.Host != "" && len(cfg.Addrs) == 0 {
+		v.port("redis.port", cfg.Port)
+	}
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		v.addf("redis.tls_cert_file", "redis.tls_cert_file and redis.tls_key_file must be set together")
+	}
+}
+
+// validator 收集校验问题，每个问题带上配置键与环境变量名，便于定位
+type validator struct {
+	problems []string
+	invalid  map[string]bool
+}
+
+func (v *validator) addf(key, format string, args ...any) {
+	if v.invalid[key] {
+		return
+	}
+	v.problems = append(v.problems, fmt.Sprintf("%s (%s): %s", key, envName(key), fmt.Sprintf(format, args...)))
+}
+
+func (v *validator) require(key, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.addf(key, "must not be empty")
+	}
+}
+
+func (v *validator) positive(key string, value int64) {
+	if value <= 0 {
+		v.addf(key, "must be positive")
+	}
+}
+
+func (v *validator) oneOf(key, value string, allowed []string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	v.addf(key, "must be one of %s, got %q", strings.Join(allowed, " / "), value)
+	return false
+}
+
+func (v *validator) port(key, value string) {
+	if value == "" {
+		return
+	}
+	if n, err := strconv.Atoi(value); err != nil || n <= 0 || n > 65535 {
+		v.addf(key, "must be a port number between 1 and 65535, got %q", value)
+	}
+}
+
+func (v *validator) oauth2Client(key string, client Oauth2ClientConfig) {
+	if client.ClientID == "" {
+		return
+	}
+	v.require(key+".client_secret", client.ClientSecret)
+	v.require(key+".redirect_url", client.RedirectURL)
+}
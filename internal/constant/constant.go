@@ -34,4 +34,32 @@ const (
 	// CtxKeyLimiter undefined
 	//	@update 2025-09-30 15:57:14
 	CtxKeyLimiter = "limiter"
+
+	// CtxKeyTenantID 已鉴权用户所属的租户ID
+	//	update 2026-10-18 19:31:14
+	CtxKeyTenantID = "tenantID"
+
+	// CtxKeySkipTenantScope undefined
+	//	update 2026-10-18 10:02:13
+	CtxKeySkipTenantScope = "skipTenantScope"
+
+	// CtxKeyForcePrimary undefined
+	//	update 2026-10-18 13:52:02
+	CtxKeyForcePrimary = "forcePrimary"
+
+	// CtxKeyDBTx undefined
+	//	update 2026-10-18 14:10:02
+	CtxKeyDBTx = "dbTx"
+
+	// CtxKeyLockToken undefined
+	//	update 2026-10-18 15:53:08
+	CtxKeyLockToken = "lockToken"
+
+	// CtxKeyLocale undefined
+	//	update 2026-10-18 16:52:02
+	CtxKeyLocale = "locale"
+
+	// CtxKeySpan undefined
+	//	update 2026-10-18 17:04:08
+	CtxKeySpan = "span"
 )
@@ -0,0 +1,385 @@
+// Package container 应用依赖容器
+//
+//	按需构建资源客户端、令牌签名器、DAO 与服务，每个依赖只构建一次，
+//	命令只初始化自己用到的依赖，测试可在首次使用前通过 Option 注入替身。
+//
+//	update 2026-10-18 18:07:02
+package container
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hcd233/go-backend-tmpl/internal/auth"
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/dao"
+	objdao "github.com/hcd233/go-backend-tmpl/internal/resource/storage/obj_dao"
+	"github.com/hcd233/go-backend-tmpl/internal/service"
+	"gorm.io/gorm"
+)
+
+// Container 应用依赖容器，零值不可用，需通过 New 创建
+//
+//	author centonhuang
+//	update 2026-10-18 18:07:08
+type Container struct {
+	database lazy[struct{}]
+	cache    lazy[struct{}]
+	storage  lazy[struct{}]
+	llm      lazy[struct{}]
+
+	accessTokenSigner  lazy[auth.JwtTokenSigner]
+	refreshTokenSigner lazy[auth.JwtTokenSigner]
+
+	userDAO         lazy[*dao.UserDAO]
+	imageObjDAO     lazy[objdao.ObjDAO]
+	thumbnailObjDAO lazy[objdao.ObjDAO]
+	backupObjDAO    lazy[objdao.BackupObjDAO]
+
+	userService         lazy[service.UserService]
+	tokenService        lazy[service.TokenService]
+	rateLimitService    lazy[service.RateLimitService]
+	githubOauth2Service lazy[service.Oauth2Service]
+	googleOauth2Service lazy[service.Oauth2Service]
+}
+
+// Option 容器选项，用于在依赖构建前注入替身
+//
+//	update 2026-10-18 18:07:14
+type Option func(c *Container)
+
+// New 创建依赖容器，创建时不初始化任何依赖
+//
+//	param opts ...Option
+//	return *Container
+//	author centonhuang
+//	update 2026-10-18 18:07:20
+func New(opts ...Option) *Container {
+	c := &Container{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithDatabase 使用给定的数据库实例，不再按配置连接数据库
+//
+//	param db *gorm.DB
+//	return Option
+//	author centonhuang
+//	update 2026-10-18 18:07:26
+func WithDatabase(db *gorm.DB) Option {
+	return func(c *Container) {
+		database.SwapDBInstance(db)
+		c.database.set(struct{}{})
+	}
+}
+
+// WithAccessTokenSigner 注入 access token 签名器
+//
+//	param signer auth.JwtTokenSigner
+//	return Option
+//	author centonhuang
+//	update 2026-10-18 18:07:32
+func WithAccessTokenSigner(signer auth.JwtTokenSigner) Option {
+	return func(c *Container) { c.accessTokenSigner.set(signer) }
+}
+
+// WithRefreshTokenSigner 注入 refresh token 签名器
+//
+//	param signer auth.JwtTokenSigner
+//	return Option
+//	author centonhuang
+//	update 2026-10-18 18:07:38
+func WithRefreshTokenSigner(signer auth.JwtTokenSigner) Option {
+	return func(c *Container) { c.refreshTokenSigner.set(signer) }
+}
+
+// WithImageObjDAO 注入图片对象DAO，注入后构建依赖它的服务时不再初始化对象存储
+//
+//	param objDAO objdao.ObjDAO
+//	return Option
+//	author centonhuang
+//	update 2026-10-18 18:07:44
+func WithImageObjDAO(objDAO objdao.ObjDAO) Option {
+	return func(c *Container) { c.imageObjDAO.set(objDAO) }
+}
+
+// WithThumbnailObjDAO 注入缩略图对象DAO
+//
+//	param objDAO objdao.ObjDAO
+//	return Option
+//	author centonhuang
+//	update 2026-10-18 18:07:50
+func WithThumbnailObjDAO(objDAO objdao.ObjDAO) Option {
+	return func(c *Container) { c.thumbnailObjDAO.set(objDAO) }
+}
+
+// WithBackupObjDAO 注入数据库备份对象DAO
+//
+//	param objDAO objdao.BackupObjDAO
+//	return Option
+//	author centonhuang
+//	update 2026-10-18 18:07:56
+func WithBackupObjDAO(objDAO objdao.BackupObjDAO) Option {
+	return func(c *Container) { c.backupObjDAO.set(objDAO) }
+}
+
+// WithUserService 注入用户服务
+//
+//	param svc service.UserService
+//	return Option
+//	author centonhuang
+//	update 2026-10-18 18:08:02
+func WithUserService(svc service.UserService) Option {
+	return func(c *Container) { c.userService.set(svc) }
+}
+
+// WithTokenService 注入令牌服务
+//
+//	param svc service.TokenService
+//	return Option
+//	author centonhuang
+//	update 2026-10-18 18:08:08
+func WithTokenService(svc service.TokenService) Option {
+	return func(c *Container) { c.tokenService.set(svc) }
+}
+
+// WithRateLimitService 注入限频策略服务
+//
+//	param svc service.RateLimitService
+//	return Option
+//	author centonhuang
+//	update 2026-10-18 18:08:14
+func WithRateLimitService(svc service.RateLimitService) Option {
+	return func(c *Container) { c.rateLimitService.set(svc) }
+}
+
+// WithOauth2Services 注入 Github 与 Google OAuth2 服务
+//
+//	param github service.Oauth2Service
+//	param google service.Oauth2Service
+//	return Option
+//	author centonhuang
+//	update 2026-10-18 18:08:20
+func WithOauth2Services(github, google service.Oauth2Service) Option {
+	return func(c *Container) {
+		c.githubOauth2Service.set(github)
+		c.googleOauth2Service.set(google)
+	}
+}
+
+// AccessTokenSigner 获取 access token 签名器
+//
+//	receiver c *Container
+//	return auth.JwtTokenSigner
+//	author centonhuang
+//	update 2026-10-18 18:08:26
+func (c *Container) AccessTokenSigner() auth.JwtTokenSigner {
+	signer, _ := c.accessTokenSigner.get(func() (auth.JwtTokenSigner, error) {
+		return auth.NewJwtTokenSigner(config.JwtAccessTokenSecret, config.JwtAccessTokenExpired), nil
+	})
+	return signer
+}
+
+// RefreshTokenSigner 获取 refresh token 签名器
+//
+//	receiver c *Container
+//	return auth.JwtTokenSigner
+//	author centonhuang
+//	update 2026-10-18 18:08:32
+func (c *Container) RefreshTokenSigner() auth.JwtTokenSigner {
+	signer, _ := c.refreshTokenSigner.get(func() (auth.JwtTokenSigner, error) {
+		return auth.NewJwtTokenSigner(config.JwtRefreshTokenSecret, config.JwtRefreshTokenExpired), nil
+	})
+	return signer
+}
+
+// UserDAO 获取用户DAO，查询时使用的数据库由调用方传入，构建时不连接数据库
+//
+//	receiver c *Container
+//	return *dao.UserDAO
+//	author centonhuang
+//	update 2026-10-18 18:08:38
+func (c *Container) UserDAO() *dao.UserDAO {
+	userDAO, _ := c.userDAO.get(func() (*dao.UserDAO, error) {
+		return dao.NewUserDAO(), nil
+	})
+	return userDAO
+}
+
+// ImageObjDAO 获取图片对象DAO，首次调用时初始化对象存储
+//
+//	receiver c *Container
+//	return objdao.ObjDAO
+//	return error
+//	author centonhuang
+//	update 2026-10-18 18:08:44
+func (c *Container) ImageObjDAO() (objdao.ObjDAO, error) {
+	return c.imageObjDAO.get(func() (objdao.ObjDAO, error) {
+		if err := c.InitObjectStorage(); err != nil {
+			return nil, err
+		}
+		return objdao.NewObjDAO(objdao.ObjectTypeImage)
+	})
+}
+
+// ThumbnailObjDAO 获取缩略图对象DAO，首次调用时初始化对象存储
+//
+//	receiver c *Container
+//	return objdao.ObjDAO
+//	return error
+//	author centonhuang
+//	update 2026-10-18 18:08:50
+func (c *Container) ThumbnailObjDAO() (objdao.ObjDAO, error) {
+	return c.thumbnailObjDAO.get(func() (objdao.ObjDAO, error) {
+		if err := c.InitObjectStorage(); err != nil {
+			return nil, err
+		}
+		return objdao.NewObjDAO(objdao.ObjectTypeThumbnail)
+	})
+}
+
+// BackupObjDAO 获取数据库备份对象DAO，首次调用时初始化对象存储
+//
+//	receiver c *Container
+//	return objdao.BackupObjDAO
+//	return error
+//	author centonhuang
+//	update 2026-10-18 18:08:56
+func (c *Container) BackupObjDAO() (objdao.BackupObjDAO, error) {
+	return c.backupObjDAO.get(func() (objdao.BackupObjDAO, error) {
+		if err := c.InitObjectStorage(); err != nil {
+			return nil, err
+		}
+		return objdao.NewBackupObjDAO()
+	})
+}
+
+// UserService 获取用户服务
+//
+//	receiver c *Container
+//	return service.UserService
+//	author centonhuang
+//	update 2026-10-18 18:09:02
+func (c *Container) UserService() service.UserService {
+	svc, _ := c.userService.get(func() (service.UserService, error) {
+		return service.NewUserService(c.UserDAO()), nil
+	})
+	return svc
+}
+
+// TokenService 获取令牌服务
+//
+//	receiver c *Container
+//	return service.TokenService
+//	author centonhuang
+//	update 2026-10-18 18:09:08
+func (c *Container) TokenService() service.TokenService {
+	svc, _ := c.tokenService.get(func() (service.TokenService, error) {
+		return service.NewTokenService(c.UserDAO(), c.AccessTokenSigner(), c.RefreshTokenSigner()), nil
+	})
+	return svc
+}
+
+// RateLimitService 获取限频策略服务
+//
+//	receiver c *Container
+//	return service.RateLimitService
+//	author centonhuang
+//	update 2026-10-18 18:09:14
+func (c *Container) RateLimitService() service.RateLimitService {
+	svc, _ := c.rateLimitService.get(func() (service.RateLimitService, error) {
+		return service.NewRateLimitService(c.UserDAO()), nil
+	})
+	return svc
+}
+
+// GithubOauth2Service 获取Github OAuth2服务，头像需要写入对象存储
+//
+//	receiver c *Container
+//	return service.Oauth2Service
+//	return error
+//	author centonhuang
+//	update 2026-10-18 18:09:20
+func (c *Container) GithubOauth2Service() (service.Oauth2Service, error) {
+	return c.githubOauth2Service.get(func() (service.Oauth2Service, error) {
+		deps, err := c.oauth2Dependencies()
+		if err != nil {
+			return nil, err
+		}
+		return service.NewGithubOauth2Service(deps), nil
+	})
+}
+
+// GoogleOauth2Service 获取Google OAuth2服务，头像需要写入对象存储
+//
+//	receiver c *Container
+//	return service.Oauth2Service
+//	return error
+//	author centonhuang
+//	update 2026-10-18 18:09:26
+func (c *Container) GoogleOauth2Service() (service.Oauth2Service, error) {
+	return c.googleOauth2Service.get(func() (service.Oauth2Service, error) {
+		deps, err := c.oauth2Dependencies()
+		if err != nil {
+			return nil, err
+		}
+		return service.NewGoogleOauth2Service(deps), nil
+	})
+}
+
+func (c *Container) oauth2Dependencies() (service.Oauth2Dependencies, error) {
+	imageObjDAO, err := c.ImageObjDAO()
+	if err != nil {
+		return service.Oauth2Dependencies{}, err
+	}
+	thumbnailObjDAO, err := c.ThumbnailObjDAO()
+	if err != nil {
+		return service.Oauth2Dependencies{}, err
+	}
+	return service.Oauth2Dependencies{
+		UserDAO:            c.UserDAO(),
+		ImageObjDAO:        imageObjDAO,
+		ThumbnailObjDAO:    thumbnailObjDAO,
+		AccessTokenSigner:  c.AccessTokenSigner(),
+		RefreshTokenSigner: c.RefreshTokenSigner(),
+	}, nil
+}
+
+// lazy 只构建一次的依赖，构建失败的错误同样只产生一次
+type lazy[T any] struct {
+	once  sync.Once
+	value T
+	err   error
+}
+
+// get 首次调用时执行 build，资源的初始化函数以 panic 报告失败，在此转换为错误
+func (l *lazy[T]) get(build func() (T, error)) (T, error) {
+	l.once.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				l.err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		l.value, l.err = build()
+	})
+	return l.value, l.err
+}
+
+// run 执行以 panic 报告失败的资源初始化函数，只执行一次
+func (l *lazy[T]) run(initFunc func()) error {
+	_, err := l.get(func() (value T, err error) {
+		initFunc()
+		return value, nil
+	})
+	return err
+}
+
+// set 在首次构建前注入依赖，之后 get 直接返回注入的值，已构建时不生效
+func (l *lazy[T]) set(value T) {
+	l.once.Do(func() {
+		l.value = value
+	})
+}
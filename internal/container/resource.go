@@ -0,0 +1,79 @@
+package container
+
+import (
+	"context"
+
+	"github.com/hcd233/go-backend-tmpl/internal/lifecycle"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/cache"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/llm"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/storage"
+)
+
+// InitDatabase 连接数据库，重复调用只连接一次
+//
+//	receiver c *Container
+//	return error
+//	author centonhuang
+//	update 2026-10-18 18:10:02
+func (c *Container) InitDatabase() error {
+	return c.database.run(database.InitDatabase)
+}
+
+// InitCache 连接Redis，未配置Redis时以内存模式运行，重复调用只连接一次
+//
+//	receiver c *Container
+//	return error
+//	author centonhuang
+//	update 2026-10-18 18:10:08
+func (c *Container) InitCache() error {
+	return c.cache.run(cache.InitCache)
+}
+
+// InitObjectStorage 初始化对象存储客户端，未配置对象存储时返回错误，重复调用只初始化一次
+//
+//	receiver c *Container
+//	return error
+//	author centonhuang
+//	update 2026-10-18 18:10:14
+func (c *Container) InitObjectStorage() error {
+	if _, err := storage.GetProvider(); err != nil {
+		return err
+	}
+	return c.storage.run(storage.InitObjectStorage)
+}
+
+// InitLLM 初始化 OpenAI 客户端，重复调用只初始化一次
+//
+//	receiver c *Container
+//	return error
+//	author centonhuang
+//	update 2026-10-18 18:10:20
+func (c *Container) InitLLM() error {
+	return c.llm.run(llm.InitOpenAIClient)
+}
+
+// ResourceHooks 资源的启动与停止逻辑，按依赖顺序排列，启动时与 InitXxx 共享同一次初始化
+//
+//	receiver c *Container
+//	return []lifecycle.Hook
+//	author centonhuang
+//	update 2026-10-18 18:10:26
+func (c *Container) ResourceHooks() []lifecycle.Hook {
+	return []lifecycle.Hook{
+		resourceHook("database", c.InitDatabase, database.CloseDatabase),
+		resourceHook("cache", c.InitCache, cache.CloseCache),
+		resourceHook("storage", c.InitObjectStorage, storage.CloseObjectStorage),
+		resourceHook("llm", c.InitLLM, llm.CloseOpenAIClient),
+	}
+}
+
+func resourceHook(name string, start func() error, stop func(ctx context.Context) error) lifecycle.Hook {
+	return lifecycle.Hook{
+		Name: name,
+		OnStart: func(context.Context) error {
+			return start()
+		},
+		OnStop: stop,
+	}
+}
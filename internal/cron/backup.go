@@ -0,0 +1,138 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/constant"
+	"github.com/hcd233/go-backend-tmpl/internal/lock"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/backup"
+	objdao "github.com/hcd233/go-backend-tmpl/internal/resource/storage/obj_dao"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	backupLockKey = "cron:backup"
+	backupLockTTL = time.Minute
+)
+
+// BackupCron 数据库定时备份任务
+//
+//	author centonhuang
+//	update 2026-10-18 12:10:03
+type BackupCron struct {
+	cron   *cron.Cron
+	spec   string
+	objDAO objdao.BackupObjDAO
+}
+
+// NewBackupCron 创建数据库定时备份任务
+//
+//	param spec string
+//	param objDAO objdao.BackupObjDAO
+//	return Cron
+//	author centonhuang
+//	update 2026-10-18 18:06:02
+func NewBackupCron(spec string, objDAO objdao.BackupObjDAO) Cron {
+	return &BackupCron{
+		cron: cron.New(
+			cron.WithLogger(newCronLoggerAdapter("BackupCron", logger.Logger())),
+			cron.WithChain(cron.SkipIfStillRunning(newCronLoggerAdapter("BackupCron", logger.Logger()))),
+		),
+		spec:   spec,
+		objDAO: objDAO,
+	}
+}
+
+// Start 启动数据库定时备份任务
+//
+//	receiver c *BackupCron
+//	return error
+//	author centonhuang
+//	update 2026-10-18 12:10:15
+func (c *BackupCron) Start() error {
+	entryID, err := c.cron.AddFunc(c.spec, c.backup)
+	if err != nil {
+		logger.Logger().Error("[BackupCron] add func error", zap.String("spec", c.spec), zap.Error(err))
+		return err
+	}
+
+	logger.Logger().Info("[BackupCron] add func success", zap.Int("entryID", int(entryID)), zap.String("spec", c.spec))
+
+	c.cron.Start()
+
+	return nil
+}
+
+// Stop 停止数据库定时备份任务，等待执行中的备份结束
+//
+//	receiver c *BackupCron
+//	param ctx context.Context
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:44:38
+func (c *BackupCron) Stop(ctx context.Context) error {
+	if err := waitStopped(ctx, c.cron); err != nil {
+		logger.Logger().Error("[BackupCron] backup is still running after shutdown deadline", zap.Error(err))
+		return err
+	}
+	logger.Logger().Info("[BackupCron] stopped")
+	return nil
+}
+
+func (c *BackupCron) backup() {
+	start, runResult := time.Now(), jobResultError
+	defer func() { recordJobRun("backup", start, runResult) }()
+
+	ctx := context.WithValue(context.Background(), constant.CtxKeyTraceID, uuid.New().String())
+	logger := logger.WithCtx(ctx)
+
+	// 多实例部署时只由获得锁的实例执行备份，锁丢失时中止本次备份
+	l, err := lock.Acquire(ctx, backupLockKey, lock.WithTTL(backupLockTTL))
+	if err != nil {
+		if errors.Is(err, lock.ErrNotAcquired) {
+			logger.Info("[BackupCron] backup is running on another instance, skip")
+			runResult = jobResultSkipped
+			return
+		}
+		logger.Error("[BackupCron] failed to acquire lock", zap.Error(err))
+		return
+	}
+	defer func() {
+		if err := l.Release(context.Background()); err != nil {
+			logger.Warn("[BackupCron] failed to release lock", zap.Error(err))
+		}
+	}()
+	logger.Info("[BackupCron] lock acquired", zap.Uint64("token", l.Token()))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-l.Lost():
+			logger.Error("[BackupCron] lock lost, cancel backup")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	result, err := backup.ToStorage(ctx, c.objDAO)
+	if err != nil {
+		logger.Error("[BackupCron] backup failed", zap.Error(err))
+		return
+	}
+	logger.Info("[BackupCron] backup success", zap.String("name", result.Name), zap.Int64("size", result.Size))
+
+	runResult = jobResultSuccess
+
+	maxAge := time.Duration(config.BackupRetentionDays) * 24 * time.Hour
+	if _, err := backup.ApplyRetention(ctx, c.objDAO, config.BackupRetentionCount, maxAge); err != nil {
+		logger.Error("[BackupCron] apply retention failed", zap.Error(err))
+		runResult = jobResultError
+	}
+}
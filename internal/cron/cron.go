@@ -4,30 +4,122 @@
 package cron
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/lifecycle"
 	"github.com/hcd233/go-backend-tmpl/internal/logger"
-	"github.com/samber/lo"
+	"github.com/hcd233/go-backend-tmpl/internal/metrics"
+	objdao "github.com/hcd233/go-backend-tmpl/internal/resource/storage/obj_dao"
+	"github.com/robfig/cron/v3"
 	"go.uber.org/zap"
 )
 
 // Cron 定时任务接口
 //
 //	@author centonhuang
-//	@update 2025-09-30 16:08:18
+//	@update 2026-10-18 17:44:14
 type Cron interface {
 	Start() error
+	// Stop 停止调度并等待执行中的任务结束，直到 ctx 结束
+	Stop(ctx context.Context) error
 }
 
+// jobs 已启动的定时任务
+var jobs []Cron
+
 // InitCronJobs 初始化定时任务
 //
+//	param backupObjDAO func() (objdao.BackupObjDAO, error) 仅在配置了 BACKUP_CRON 时调用，未启用备份时无需对象存储
+//	return error
 //	author centonhuang
-//	update 2024-12-09 15:55:20
-func InitCronJobs() {
+//	update 2026-10-18 18:06:08
+func InitCronJobs(backupObjDAO func() (objdao.BackupObjDAO, error)) error {
 	exampleCron := NewExampleCron()
-	lo.Must0(exampleCron.Start())
+	if err := exampleCron.Start(); err != nil {
+		return err
+	}
+	jobs = append(jobs, exampleCron)
+
+	if config.BackupCron != "" {
+		objDAO, err := backupObjDAO()
+		if err != nil {
+			return err
+		}
+		backupCron := NewBackupCron(config.BackupCron, objDAO)
+		if err := backupCron.Start(); err != nil {
+			return err
+		}
+		jobs = append(jobs, backupCron)
+	}
 
 	logger.Logger().Info("[Cron] Init cron jobs")
+	return nil
+}
+
+// StopCronJobs 停止所有定时任务的调度并等待执行中的任务结束
+//
+//	param ctx context.Context
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:44:26
+func StopCronJobs(ctx context.Context) error {
+	var errs []error
+	for _, job := range jobs {
+		if err := job.Stop(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	jobs = nil
+	return errors.Join(errs...)
+}
+
+// LifecycleHook 定时任务的启动与停止逻辑
+//
+//	param backupObjDAO func() (objdao.BackupObjDAO, error)
+//	return lifecycle.Hook
+//	author centonhuang
+//	update 2026-10-18 18:06:14
+func LifecycleHook(backupObjDAO func() (objdao.BackupObjDAO, error)) lifecycle.Hook {
+	return lifecycle.Hook{
+		Name: "cron",
+		OnStart: func(context.Context) error {
+			return InitCronJobs(backupObjDAO)
+		},
+		OnStop: StopCronJobs,
+	}
+}
+
+// waitStopped 停止调度器并等待执行中的任务结束，直到 ctx 结束
+func waitStopped(ctx context.Context, c *cron.Cron) error {
+	select {
+	case <-c.Stop().Done():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// 定时任务单次执行的结果，作为 cron_job_runs_total 的 result 标签
+const (
+	jobResultSuccess = "success"
+	jobResultSkipped = "skipped"
+	jobResultError   = "error"
+)
+
+// recordJobRun 记录定时任务单次执行的结果与耗时
+//
+//	param job string
+//	param start time.Time
+//	param result string
+//	author centonhuang
+//	update 2026-10-18 17:34:02
+func recordJobRun(job string, start time.Time, result string) {
+	metrics.CronJobRunsTotal.WithLabelValues(job, result).Inc()
+	metrics.CronJobDuration.WithLabelValues(job, result).Observe(time.Since(start).Seconds())
 }
 
 type cronLoggerAdapter struct {
@@ -2,6 +2,7 @@ package cron
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hcd233/go-backend-tmpl/internal/constant"
@@ -39,7 +40,7 @@ func NewExampleCron() Cron {
 //	@update 2025-09-30 16:11:28
 func (c *ExampleCron) Start() error {
 	// debug set 10 seconds
-	entryID, err := c.cron.AddFunc("every 10s", c.doSomething)
+	entryID, err := c.cron.AddFunc("@every 10s", c.doSomething)
 	// c.cron.AddFunc("daily", c.deliverQuotas)
 	if err != nil {
 		logger.Logger().Error("[ExampleCron] add func error", zap.Error(err))
@@ -53,7 +54,25 @@ func (c *ExampleCron) Start() error {
 	return nil
 }
 
+// Stop 停止示例定时任务
+//
+//	receiver c *ExampleCron
+//	param ctx context.Context
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:44:44
+func (c *ExampleCron) Stop(ctx context.Context) error {
+	if err := waitStopped(ctx, c.cron); err != nil {
+		return err
+	}
+	logger.Logger().Info("[ExampleCron] stopped")
+	return nil
+}
+
 func (c *ExampleCron) doSomething() {
+	start := time.Now()
+	defer func() { recordJobRun("example", start, jobResultSuccess) }()
+
 	ctx := context.WithValue(context.Background(), constant.CtxKeyTraceID, uuid.New().String())
 	logger := logger.WithCtx(ctx)
 
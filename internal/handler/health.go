@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hcd233/go-backend-tmpl/internal/health"
+	"github.com/hcd233/go-backend-tmpl/internal/protocol"
+	"github.com/hcd233/go-backend-tmpl/internal/util"
+	"github.com/samber/lo"
+)
+
+// HealthHandler 存活与就绪检查处理器
+//
+//	author centonhuang
+//	update 2026-10-18 17:53:02
+type HealthHandler interface {
+	HandleLiveness(c *fiber.Ctx) error
+	HandleReadiness(c *fiber.Ctx) error
+}
+
+type healthHandler struct{}
+
+// NewHealthHandler 创建存活与就绪检查处理器
+//
+//	return HealthHandler
+//	author centonhuang
+//	update 2026-10-18 17:53:08
+func NewHealthHandler() HealthHandler {
+	return &healthHandler{}
+}
+
+// HandleLiveness 处理存活检查请求，进程能处理请求即为存活，不检查依赖
+//
+//	@Summary		存活检查
+//	@Description	进程存活即返回200，不检查依赖，用于存活探针
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	protocol.HTTPResponse{data=protocol.LivenessResponse,error=nil}
+//	@Router			/healthz [get]
+//	receiver h *healthHandler
+//	param c *fiber.Ctx
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:53:14
+func (h *healthHandler) HandleLiveness(c *fiber.Ctx) error {
+	return util.SendHTTPResponse(c, protocol.LivenessResponse{Status: health.StatusOK})
+}
+
+// HandleReadiness 处理就绪检查请求，关键依赖均可用时返回200，否则返回503
+//
+//	@Summary		就绪检查
+//	@Description	并发检查各依赖并返回每个依赖的状态与耗时，结果短暂缓存；非关键依赖不可用时返回降级但仍为200，服务退出期间返回503
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	protocol.HTTPResponse{data=protocol.ReadinessResponse,error=nil}
+//	@Failure		503	{object}	protocol.HTTPResponse{data=protocol.ReadinessResponse,error=nil}
+//	@Router			/readyz [get]
+//	receiver h *healthHandler
+//	param c *fiber.Ctx
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:53:20
+func (h *healthHandler) HandleReadiness(c *fiber.Ctx) error {
+	report := health.Readiness()
+
+	rsp := &protocol.ReadinessResponse{
+		Status: report.Status,
+		Components: lo.Map(report.Components, func(component health.Component, _ int) *protocol.HealthComponent {
+			return &protocol.HealthComponent{
+				Name:      component.Name,
+				Status:    component.Status,
+				Critical:  component.Critical,
+				LatencyMs: float64(component.Latency.Microseconds()) / 1000,
+			}
+		}),
+		CheckedAt: report.CheckedAt.Format(time.RFC3339Nano),
+	}
+
+	status := fiber.StatusOK
+	if !report.Ready() {
+		status = fiber.StatusServiceUnavailable
+	}
+	return util.SendHTTPResponseWithStatus(c, status, rsp)
+}
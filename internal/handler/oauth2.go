@@ -17,21 +17,15 @@ type oauth2Handler struct {
 	svc service.Oauth2Service
 }
 
-// NewGithubOauth2Handler 创建Github OAuth2处理器
+// NewOauth2Handler 创建OAuth2处理器，登录与回调的提供商由 svc 决定
 //
+//	param svc service.Oauth2Service
 //	return Oauth2Handler
 //	author centonhuang
-//	update 2025-01-05 13:43:43
-func NewGithubOauth2Handler() Oauth2Handler {
+//	update 2026-10-18 18:05:20
+func NewOauth2Handler(svc service.Oauth2Service) Oauth2Handler {
 	return &oauth2Handler{
-		svc: service.NewGithubOauth2Service(),
-	}
-}
-
-// NewGoogleOauth2Handler 创建Google OAuth2处理器
-func NewGoogleOauth2Handler() Oauth2Handler {
-	return &oauth2Handler{
-		svc: service.NewGoogleOauth2Service(),
+		svc: svc,
 	}
 }
 
@@ -51,14 +45,16 @@ func NewGoogleOauth2Handler() Oauth2Handler {
 //	receiver h *oauth2Handler
 //	param c *fiber.Ctx error
 //	author centonhuang
-//	update 2025-01-05 13:43:42
+//	update 2026-10-18 17:46:02
 func (h *oauth2Handler) HandleLogin(c *fiber.Ctx) error {
 	req := &protocol.LoginRequest{}
 
-	rsp, err := h.svc.Login(c.Context(), req)
+	rsp, err := h.svc.Login(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
 
-	util.SendHTTPResponse(c, rsp, err)
-	return nil
+	return util.SendHTTPResponse(c, rsp)
 }
 
 // HandleCallback OAuth2回调
@@ -79,12 +75,11 @@ func (h *oauth2Handler) HandleLogin(c *fiber.Ctx) error {
 //	receiver h *oauth2Handler
 //	param c *fiber.Ctx error
 //	author centonhuang
-//	update 2025-01-05 13:43:36
+//	update 2026-10-18 17:46:08
 func (h *oauth2Handler) HandleCallback(c *fiber.Ctx) error {
 	params := protocol.OAuth2CallbackParam{}
 	if err := c.QueryParser(&params); err != nil {
-		util.SendHTTPResponse(c, nil, protocol.ErrInternalError)
-		return nil
+		return protocol.ErrBadRequest.WithCause(err)
 	}
 
 	req := &protocol.CallbackRequest{
@@ -92,8 +87,10 @@ func (h *oauth2Handler) HandleCallback(c *fiber.Ctx) error {
 		State: params.State,
 	}
 
-	rsp, err := h.svc.Callback(c.Context(), req)
+	rsp, err := h.svc.Callback(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
 
-	util.SendHTTPResponse(c, rsp, err)
-	return nil
+	return util.SendHTTPResponse(c, rsp)
 }
@@ -43,6 +43,5 @@ func (h *pingHandler) HandlePing(c *fiber.Ctx) error {
 		Status: "ok",
 	}
 
-	util.SendHTTPResponse(c, rsp, nil)
-	return nil
+	return util.SendHTTPResponse(c, rsp)
 }
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hcd233/go-backend-tmpl/internal/constant"
+	"github.com/hcd233/go-backend-tmpl/internal/protocol"
+	"github.com/hcd233/go-backend-tmpl/internal/service"
+	"github.com/hcd233/go-backend-tmpl/internal/util"
+)
+
+// RateLimitHandler 限频策略处理器
+//
+//	author centonhuang
+//	update 2026-10-18 16:26:02
+type RateLimitHandler interface {
+	HandleGetPolicy(c *fiber.Ctx) error
+	HandleSetOverride(c *fiber.Ctx) error
+	HandleDeleteOverride(c *fiber.Ctx) error
+}
+
+type rateLimitHandler struct {
+	svc service.RateLimitService
+}
+
+// NewRateLimitHandler 创建限频策略处理器
+//
+//	param svc service.RateLimitService
+//	return RateLimitHandler
+//	author centonhuang
+//	update 2026-10-18 18:05:14
+func NewRateLimitHandler(svc service.RateLimitService) RateLimitHandler {
+	return &rateLimitHandler{
+		svc: svc,
+	}
+}
+
+// HandleGetPolicy 获取用户限频策略
+//
+//	@Summary		获取用户限频策略
+//	@Description	获取用户生效的限频窗口与配额，仅管理员可用
+//	@Tags			rateLimit
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			path	path		protocol.UserURI	true	"用户ID"
+//	@Success		200		{object}	protocol.HTTPResponse{data=protocol.GetRateLimitPolicyResponse,error=nil}
+//	@Failure		400		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		401		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		403		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		500		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Router			/v1/user/{userID}/rateLimit [get]
+//	param c *fiber.Ctx
+//	author centonhuang
+//	update 2026-10-18 17:46:14
+func (h *rateLimitHandler) HandleGetPolicy(c *fiber.Ctx) error {
+	uri := c.Locals(constant.CtxKeyURI).(*protocol.UserURI)
+
+	req := &protocol.GetRateLimitPolicyRequest{
+		UserID: uri.UserID,
+	}
+
+	rsp, err := h.svc.GetPolicy(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return util.SendHTTPResponse(c, rsp)
+}
+
+// HandleSetOverride 设置用户限频策略
+//
+//	@Summary		设置用户限频策略
+//	@Description	为用户单独设置限频窗口与配额，替代其权限对应的默认策略，仅管理员可用
+//	@Tags			rateLimit
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			path	path		protocol.UserURI					true	"用户ID"
+//	@Param			body	body		protocol.SetRateLimitOverrideBody	true	"限频策略，窗口时长如 1s、24h"
+//	@Success		200		{object}	protocol.HTTPResponse{data=protocol.SetRateLimitOverrideResponse,error=nil}
+//	@Failure		400		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		401		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		403		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		500		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Router			/v1/user/{userID}/rateLimit [put]
+//	param c *fiber.Ctx
+//	author centonhuang
+//	update 2026-10-18 17:46:20
+func (h *rateLimitHandler) HandleSetOverride(c *fiber.Ctx) error {
+	uri := c.Locals(constant.CtxKeyURI).(*protocol.UserURI)
+	body := c.Locals(constant.CtxKeyBody).(*protocol.SetRateLimitOverrideBody)
+
+	req := &protocol.SetRateLimitOverrideRequest{
+		UserID:  uri.UserID,
+		Windows: body.Windows,
+		Quotas:  body.Quotas,
+	}
+
+	rsp, err := h.svc.SetOverride(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return util.SendHTTPResponse(c, rsp)
+}
+
+// HandleDeleteOverride 删除用户限频策略
+//
+//	@Summary		删除用户限频策略
+//	@Description	删除用户的单独限频策略，恢复为其权限对应的默认策略，仅管理员可用
+//	@Tags			rateLimit
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			path	path		protocol.UserURI	true	"用户ID"
+//	@Success		200		{object}	protocol.HTTPResponse{data=protocol.DeleteRateLimitOverrideResponse,error=nil}
+//	@Failure		400		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		401		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		403		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		500		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Router			/v1/user/{userID}/rateLimit [delete]
+//	param c *fiber.Ctx
+//	author centonhuang
+//	update 2026-10-18 17:46:26
+func (h *rateLimitHandler) HandleDeleteOverride(c *fiber.Ctx) error {
+	uri := c.Locals(constant.CtxKeyURI).(*protocol.UserURI)
+
+	req := &protocol.DeleteRateLimitOverrideRequest{
+		UserID: uri.UserID,
+	}
+
+	rsp, err := h.svc.DeleteOverride(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return util.SendHTTPResponse(c, rsp)
+}
@@ -22,12 +22,13 @@ type tokenHandler struct {
 
 // NewTokenHandler 创建令牌处理器
 //
+//	param svc service.TokenService
 //	return TokenHandler
 //	author centonhuang
-//	update 2025-01-04 15:56:04
-func NewTokenHandler() TokenHandler {
+//	update 2026-10-18 18:05:02
+func NewTokenHandler(svc service.TokenService) TokenHandler {
 	return &tokenHandler{
-		svc: service.NewTokenService(),
+		svc: svc,
 	}
 }
 
@@ -46,7 +47,7 @@ func NewTokenHandler() TokenHandler {
 //	receiver s *tokenHandler
 //	param c *fiber.Ctx error
 //	author centonhuang
-//	update 2025-01-04 15:56:10
+//	update 2026-10-18 17:46:32
 func (h *tokenHandler) HandleRefreshToken(c *fiber.Ctx) error {
 	body := c.Locals(constant.CtxKeyBody).(*protocol.RefreshTokenBody)
 
@@ -54,8 +55,10 @@ func (h *tokenHandler) HandleRefreshToken(c *fiber.Ctx) error {
 		RefreshToken: body.RefreshToken,
 	}
 
-	rsp, err := h.svc.RefreshToken(c.Context(), req)
+	rsp, err := h.svc.RefreshToken(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
 
-	util.SendHTTPResponse(c, rsp, err)
-	return nil
+	return util.SendHTTPResponse(c, rsp)
 }
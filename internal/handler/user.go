@@ -16,6 +16,7 @@ type UserHandler interface {
 	HandleGetCurUserInfo(c *fiber.Ctx) error
 	HandleGetUserInfo(c *fiber.Ctx) error
 	HandleUpdateInfo(c *fiber.Ctx) error
+	HandleListUsers(c *fiber.Ctx) error
 }
 
 type userHandler struct {
@@ -24,12 +25,13 @@ type userHandler struct {
 
 // NewUserHandler 创建用户处理器
 //
+//	param svc service.UserService
 //	return UserHandler
 //	author centonhuang
-//	update 2024-12-08 16:59:38
-func NewUserHandler() UserHandler {
+//	update 2026-10-18 18:05:08
+func NewUserHandler(svc service.UserService) UserHandler {
 	return &userHandler{
-		svc: service.NewUserService(),
+		svc: svc,
 	}
 }
 
@@ -49,7 +51,7 @@ func NewUserHandler() UserHandler {
 //	@Router			/v1/user/current [get]
 //	param c *fiber.Ctx
 //	author centonhuang
-//	update 2025-01-04 15:56:30
+//	update 2026-10-18 17:46:38
 func (h *userHandler) HandleGetCurUserInfo(c *fiber.Ctx) error {
 	userID := c.Locals(constant.CtxKeyUserID).(uint)
 
@@ -57,10 +59,13 @@ func (h *userHandler) HandleGetCurUserInfo(c *fiber.Ctx) error {
 		UserID: userID,
 	}
 
-	rsp, err := h.svc.GetCurUserInfo(c.Context(), req)
+	rsp, err := h.svc.GetCurUserInfo(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
 
-	util.SendHTTPResponse(c, rsp, err)
-	return nil
+	c.Set(fiber.HeaderETag, util.FormatETag(rsp.Version))
+	return util.SendHTTPResponse(c, rsp)
 }
 
 // GetUserInfoHandler 用户信息
@@ -80,7 +85,7 @@ func (h *userHandler) HandleGetCurUserInfo(c *fiber.Ctx) error {
 //	@Router			/v1/user/{userID} [get]
 //	param c *fiber.Ctx
 //	author centonhuang
-//	update 2025-01-04 15:56:30
+//	update 2026-10-18 17:46:44
 func (h *userHandler) HandleGetUserInfo(c *fiber.Ctx) error {
 	uri := c.Locals(constant.CtxKeyURI).(*protocol.UserURI)
 
@@ -88,10 +93,12 @@ func (h *userHandler) HandleGetUserInfo(c *fiber.Ctx) error {
 		UserID: uri.UserID,
 	}
 
-	rsp, err := h.svc.GetUserInfo(c.Context(), req)
+	rsp, err := h.svc.GetUserInfo(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
 
-	util.SendHTTPResponse(c, rsp, err)
-	return nil
+	return util.SendHTTPResponse(c, rsp)
 }
 
 // UpdateInfoHandler 更新用户信息
@@ -102,27 +109,76 @@ func (h *userHandler) HandleGetUserInfo(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		ApiKeyAuth
-//	@Param			body	body		protocol.UpdateUserBody	true	"更新用户信息请求"
-//	@Success		200		{object}	protocol.HTTPResponse{data=protocol.UpdateUserInfoResponse,error=nil}
-//	@Failure		400		{object}	protocol.HTTPResponse{data=nil,error=string}
-//	@Failure		401		{object}	protocol.HTTPResponse{data=nil,error=string}
-//	@Failure		403		{object}	protocol.HTTPResponse{data=nil,error=string}
-//	@Failure		500		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Param			body			body		protocol.UpdateUserBody	true	"更新用户信息请求"
+//	@Param			If-Match		header		string					false	"获取当前用户信息时返回的ETag，携带时仅在数据未被修改时更新"
+//	@Param			Idempotency-Key	header		string					false	"幂等键，重试时携带相同的键将重放首次请求的响应"
+//	@Success		200				{object}	protocol.HTTPResponse{data=protocol.UpdateUserInfoResponse,error=nil}
+//	@Failure		400				{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		401				{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		403				{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		409				{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		422				{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		500				{object}	protocol.HTTPResponse{data=nil,error=string}
 //	@Router			/v1/user [patch]
 //	param c *fiber.Ctx
 //	author centonhuang
-//	update 2025-01-04 15:56:40
+//	update 2026-10-18 17:46:50
 func (h *userHandler) HandleUpdateInfo(c *fiber.Ctx) error {
 	userID := c.Locals(constant.CtxKeyUserID).(uint)
 	body := c.Locals(constant.CtxKeyBody).(*protocol.UpdateUserBody)
 
+	expectedVersion, err := util.ParseIfMatch(c.Get(fiber.HeaderIfMatch))
+	if err != nil {
+		return protocol.ErrBadRequest.WithMessageKey("error.request.invalidIfMatch", nil).WithCause(err)
+	}
+
 	req := &protocol.UpdateUserInfoRequest{
 		UserID:          userID,
 		UpdatedUserName: body.UserName,
+		UpdatedLocale:   body.Locale,
+		ExpectedVersion: expectedVersion,
+	}
+
+	rsp, err := h.svc.UpdateUserInfo(c.UserContext(), req)
+	if err != nil {
+		return err
 	}
 
-	rsp, err := h.svc.UpdateUserInfo(c.Context(), req)
+	c.Set(fiber.HeaderETag, util.FormatETag(rsp.Version))
+	return util.SendHTTPResponse(c, rsp)
+}
+
+// HandleListUsers 用户列表
+//
+//	@Summary		获取用户列表
+//	@Description	游标分页获取用户列表，支持 filter[field][op]=value 过滤与 sort=-field 排序，仅管理员可用
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			cursor	query		string	false	"上一页响应中的 nextCursor 或 prevCursor"
+//	@Param			limit	query		int		false	"每页数量，默认20，最大100"
+//	@Param			sort	query		string	false	"排序字段，-前缀表示倒序，如 -created_at"
+//	@Success		200		{object}	protocol.HTTPResponse{data=protocol.ListUsersResponse,error=nil}
+//	@Failure		400		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		401		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		403		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Failure		500		{object}	protocol.HTTPResponse{data=nil,error=string}
+//	@Router			/v1/user [get]
+//	param c *fiber.Ctx
+//	author centonhuang
+//	update 2026-10-18 17:46:02
+func (h *userHandler) HandleListUsers(c *fiber.Ctx) error {
+	param := c.Locals(constant.CtxKeyParam).(*protocol.ListParam)
+
+	req := &protocol.ListUsersRequest{
+		Param: param,
+	}
+
+	rsp, err := h.svc.ListUsers(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
 
-	util.SendHTTPResponse(c, rsp, err)
-	return nil
+	return util.SendHTTPResponse(c, rsp)
 }
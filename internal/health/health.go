@@ -0,0 +1,244 @@
+// Package health 存活与就绪检查
+//
+//	资源初始化时以 Register 注册依赖检查，就绪检查并发执行所有检查并短暂缓存结果，
+//	HEALTH_OPTIONAL_CHECKS 中的依赖为非关键依赖，不可用时只降级不影响就绪，服务开始退出后立即返回未就绪。
+//
+//	update 2026-10-18 17:50:02
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/lifecycle"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// 就绪状态
+const (
+	// StatusOK 所有依赖可用
+	StatusOK = "ok"
+	// StatusDegraded 关键依赖可用，部分非关键依赖不可用
+	StatusDegraded = "degraded"
+	// StatusUnavailable 存在不可用的关键依赖
+	StatusUnavailable = "unavailable"
+	// StatusShuttingDown 服务正在退出
+	StatusShuttingDown = "shuttingDown"
+)
+
+// 依赖状态
+const (
+	// ComponentUp 依赖可用
+	ComponentUp = "up"
+	// ComponentDown 依赖不可用或检查超时
+	ComponentDown = "down"
+)
+
+// CheckFunc 依赖检查函数，返回 nil 表示依赖可用
+//
+//	update 2026-10-18 17:50:08
+type CheckFunc func(ctx context.Context) error
+
+// Component 单个依赖的检查结果
+//
+//	author centonhuang
+//	update 2026-10-18 17:50:14
+type Component struct {
+	Name     string
+	Status   string
+	Critical bool
+	Latency  time.Duration
+}
+
+// Report 就绪检查结果
+//
+//	author centonhuang
+//	update 2026-10-18 17:50:20
+type Report struct {
+	Status     string
+	Components []Component
+	CheckedAt  time.Time
+}
+
+// Ready 是否可以接收流量
+//
+//	receiver r *Report
+//	return bool
+//	author centonhuang
+//	update 2026-10-18 17:50:26
+func (r *Report) Ready() bool {
+	return r.Status == StatusOK || r.Status == StatusDegraded
+}
+
+var (
+	checksMu sync.RWMutex
+	checks   = map[string]CheckFunc{}
+
+	reportMu   sync.Mutex
+	lastReport *Report
+
+	shuttingDown atomic.Bool
+)
+
+// Register 注册依赖检查，同名检查后注册的覆盖先注册的
+//
+//	param name string
+//	param check CheckFunc
+//	author centonhuang
+//	update 2026-10-18 17:50:32
+func Register(name string, check CheckFunc) {
+	checksMu.Lock()
+	defer checksMu.Unlock()
+
+	checks[name] = check
+}
+
+// SetShuttingDown 标记服务正在退出，之后的就绪检查均返回未就绪
+//
+//	author centonhuang
+//	update 2026-10-18 17:50:38
+func SetShuttingDown() {
+	if !shuttingDown.Swap(true) {
+		logger.Logger().Info("[Health] Marked as not ready for shutdown")
+	}
+}
+
+// Readiness 返回就绪检查结果，HEALTH_CACHE_TTL 内重复调用返回缓存的结果
+//
+//	检查与请求上下文无关，请求取消不会中断检查，每个检查最长执行 HEALTH_CHECK_TIMEOUT
+//
+//	return *Report
+//	author centonhuang
+//	update 2026-10-18 17:50:44
+func Readiness() *Report {
+	if shuttingDown.Load() {
+		return &Report{Status: StatusShuttingDown, CheckedAt: time.Now()}
+	}
+
+	reportMu.Lock()
+	defer reportMu.Unlock()
+
+	if lastReport != nil && time.Since(lastReport.CheckedAt) < config.HealthCacheTTL {
+		return lastReport
+	}
+
+	report := runChecks()
+	logTransitions(lastReport, report)
+	lastReport = report
+	return report
+}
+
+func runChecks() *Report {
+	checksMu.RLock()
+	registered := make(map[string]CheckFunc, len(checks))
+	for name, check := range checks {
+		registered[name] = check
+	}
+	checksMu.RUnlock()
+
+	report := &Report{Status: StatusOK, CheckedAt: time.Now()}
+	components := make(chan Component, len(registered))
+	for name, check := range registered {
+		go func() {
+			components <- runCheck(name, check)
+		}()
+	}
+
+	for range registered {
+		component := <-components
+		report.Components = append(report.Components, component)
+		if component.Status == ComponentUp {
+			continue
+		}
+		if component.Critical {
+			report.Status = StatusUnavailable
+		} else if report.Status == StatusOK {
+			report.Status = StatusDegraded
+		}
+	}
+	sort.Slice(report.Components, func(i, j int) bool {
+		return report.Components[i].Name < report.Components[j].Name
+	})
+	return report
+}
+
+// runCheck 执行单个检查，检查函数不响应上下文取消时也在超时后返回
+func runCheck(name string, check CheckFunc) Component {
+	ctx, cancel := context.WithTimeout(context.Background(), config.HealthCheckTimeout)
+	defer cancel()
+
+	component := Component{
+		Name:     name,
+		Status:   ComponentDown,
+		Critical: !lo.Contains(config.HealthOptionalChecks, name),
+	}
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	component.Latency = time.Since(start)
+
+	if err != nil {
+		logger.Logger().Warn("[Health] Check failed", zap.String("name", name), zap.Bool("critical", component.Critical), zap.Duration("latency", component.Latency), zap.Error(err))
+		return component
+	}
+	component.Status = ComponentUp
+	return component
+}
+
+// logTransitions 就绪状态变化时记录日志
+func logTransitions(previous, current *Report) {
+	if previous != nil && previous.Status == current.Status {
+		return
+	}
+	down := lo.FilterMap(current.Components, func(c Component, _ int) (string, bool) {
+		return c.Name, c.Status == ComponentDown
+	})
+	if current.Status == StatusOK {
+		logger.Logger().Info("[Health] Readiness changed", zap.String("status", current.Status))
+		return
+	}
+	logger.Logger().Warn("[Health] Readiness changed", zap.String("status", current.Status), zap.Strings("down", down))
+}
+
+// LifecycleHook 停止时最先标记为未就绪，并等待 HealthDrainDelay 让负载均衡摘除实例后再停止后续服务，需在所有服务之后注册
+//
+//	return lifecycle.Hook
+//	author centonhuang
+//	update 2026-10-18 20:04:14
+func LifecycleHook() lifecycle.Hook {
+	return lifecycle.Hook{
+		Name: "health",
+		OnStop: func(ctx context.Context) error {
+			SetShuttingDown()
+			if config.HealthDrainDelay <= 0 {
+				return nil
+			}
+
+			logger.Logger().Info("[Health] Waiting for load balancers to drain traffic", zap.Duration("delay", config.HealthDrainDelay))
+			timer := time.NewTimer(config.HealthDrainDelay)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
@@ -0,0 +1,287 @@
+package i18n
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bytedance/sonic"
+)
+
+const (
+	errorKeyPrefix      = "error."
+	validationKeyPrefix = "validation."
+	bindingTag          = "binding"
+)
+
+// bindingModifiers binding 标签中不产生错误信息的修饰符
+var bindingModifiers = map[string]struct{}{
+	"omitempty": {}, "omitnil": {}, "dive": {}, "keys": {}, "endkeys": {},
+	"structonly": {}, "nostructlevel": {}, "isdefault": {},
+}
+
+// skipDirs 扫描源码时跳过的目录
+var skipDirs = map[string]struct{}{
+	".git": {}, "vendor": {}, "docs": {}, "node_modules": {},
+}
+
+// Extract 扫描目录下的 Go 源码，收集代码中以字面量引用的消息键
+//
+//	收集 NewError 的错误码（error.<code>）、WithMessageKey 与 i18n.T/Plural/Translate 的消息键，
+//	以及 binding 标签中的校验规则（validation.<rule>）
+//
+//	param root string
+//	return []string 按字典序排序的消息键
+//	return error
+//	author centonhuang
+//	update 2026-10-18 16:57:02
+func Extract(root string) ([]string, error) {
+	keys := map[string]struct{}{}
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if _, ok := skipDirs[d.Name()]; ok {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.CallExpr:
+				if key, ok := callKey(node); ok {
+					keys[key] = struct{}{}
+				}
+			case *ast.Field:
+				for _, rule := range bindingRules(node) {
+					keys[validationKeyPrefix+rule] = struct{}{}
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	slices.Sort(sorted)
+	return sorted, nil
+}
+
+// Missing 返回消息目录中缺失或未翻译的键
+//
+//	validation.<rule> 在目录中存在 validation.<rule> 或任一 validation.<rule>.<kind> 时视为已翻译
+//
+//	param catalog map[string]map[string]string
+//	param keys []string
+//	return []string
+//	author centonhuang
+//	update 2026-10-18 16:57:08
+func Missing(catalog map[string]map[string]string, keys []string) []string {
+	var missing []string
+	for _, key := range keys {
+		if translated(catalog, key) {
+			continue
+		}
+		if strings.HasPrefix(key, validationKeyPrefix) && hasTranslatedPrefix(catalog, key+".") {
+			continue
+		}
+		missing = append(missing, key)
+	}
+	return missing
+}
+
+// LoadCatalogFile 读取消息目录文件，文件不存在时返回空目录
+//
+//	param path string
+//	return map[string]map[string]string
+//	return error
+//	author centonhuang
+//	update 2026-10-18 16:57:14
+func LoadCatalogFile(path string) (map[string]map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseCatalog(data)
+}
+
+// WriteCatalogFile 按键排序写入消息目录文件，只有 other 形式的条目写为字符串
+//
+//	param path string
+//	param catalog map[string]map[string]string
+//	return error
+//	author centonhuang
+//	update 2026-10-18 16:57:20
+func WriteCatalogFile(path string, catalog map[string]map[string]string) error {
+	keys := make([]string, 0, len(catalog))
+	for key := range catalog {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	for i, key := range keys {
+		buf.WriteString("  " + strconv.Quote(key) + ": ")
+		if err := writeForms(&buf, catalog[key]); err != nil {
+			return err
+		}
+		if i < len(keys)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n")
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// CatalogPath 返回语言消息目录文件的路径
+//
+//	param dir string
+//	param locale string
+//	return string
+//	author centonhuang
+//	update 2026-10-18 16:57:26
+func CatalogPath(dir, locale string) string {
+	return filepath.Join(dir, locale+catalogSuffix)
+}
+
+// writeForms 写入单个条目，复数类别按 CLDR 顺序排列
+func writeForms(buf *bytes.Buffer, forms map[string]string) error {
+	if len(forms) <= 1 {
+		value, err := sonic.MarshalString(forms[pluralOther])
+		if err != nil {
+			return err
+		}
+		buf.WriteString(value)
+		return nil
+	}
+
+	buf.WriteString("{\n")
+	written := 0
+	for _, form := range []string{"zero", "one", "two", "few", "many", pluralOther} {
+		message, ok := forms[form]
+		if !ok {
+			continue
+		}
+		value, err := sonic.MarshalString(message)
+		if err != nil {
+			return err
+		}
+		buf.WriteString("    " + strconv.Quote(form) + ": " + value)
+		if written++; written < len(forms) {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("  }")
+	return nil
+}
+
+// callKey 取调用表达式中以字面量传入的消息键
+func callKey(call *ast.CallExpr) (string, bool) {
+	var name, pkg string
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		name = fun.Name
+	case *ast.SelectorExpr:
+		name = fun.Sel.Name
+		if ident, ok := fun.X.(*ast.Ident); ok {
+			pkg = ident.Name
+		}
+	default:
+		return "", false
+	}
+
+	switch {
+	case name == "NewError":
+		code, ok := stringArg(call, 0)
+		return errorKeyPrefix + code, ok
+	case name == "WithMessageKey":
+		return stringArg(call, 0)
+	case pkg == "i18n" && (name == "T" || name == "Plural" || name == "Translate"):
+		return stringArg(call, 1)
+	}
+	return "", false
+}
+
+func stringArg(call *ast.CallExpr, index int) (string, bool) {
+	if len(call.Args) <= index {
+		return "", false
+	}
+	lit, ok := call.Args[index].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil && value != ""
+}
+
+// bindingRules 取结构体字段 binding 标签中的校验规则，如 required,min=1 -> required, min
+func bindingRules(field *ast.Field) []string {
+	if field.Tag == nil {
+		return nil
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return nil
+	}
+
+	var rules []string
+	for _, rule := range strings.Split(reflect.StructTag(tag).Get(bindingTag), ",") {
+		for _, alternative := range strings.Split(rule, "|") {
+			name, _, _ := strings.Cut(alternative, "=")
+			if _, ok := bindingModifiers[name]; name == "" || ok {
+				continue
+			}
+			rules = append(rules, name)
+		}
+	}
+	return rules
+}
+
+func translated(catalog map[string]map[string]string, key string) bool {
+	for _, message := range catalog[key] {
+		if message != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTranslatedPrefix(catalog map[string]map[string]string, prefix string) bool {
+	for key := range catalog {
+		if strings.HasPrefix(key, prefix) && translated(catalog, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,276 @@
+// Package i18n 消息目录与语言协商
+//
+//	消息目录按语言保存在 locales/<locale>.json 中，键为点分的消息标识，如 error.NoPermission。
+//	值为字符串，或按 CLDR 复数类别（zero/one/two/few/many/other）区分的对象；
+//	消息中的 {name} 占位符由调用方传入的参数替换。
+//
+//	update 2026-10-18 16:50:02
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/zh"
+	"github.com/gofiber/fiber/v2"
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/constant"
+	"github.com/samber/lo"
+)
+
+const (
+
+	// LocaleEN 英文
+	//
+	//	update 2026-10-18 16:50:08
+	LocaleEN = "en"
+
+	// LocaleZH 中文
+	//
+	//	update 2026-10-18 16:50:08
+	LocaleZH = "zh"
+
+	catalogDir    = "locales"
+	catalogSuffix = ".json"
+	pluralOther   = "other"
+)
+
+//go:embed locales/*.json
+var catalogFS embed.FS
+
+// Args 消息参数，键为占位符名
+//
+//	author centonhuang
+//	update 2026-10-18 16:50:14
+type Args map[string]interface{}
+
+// pluralRules 各语言的复数规则
+var pluralRules = map[string]locales.Translator{
+	LocaleEN: en.New(),
+	LocaleZH: zh.New(),
+}
+
+// catalogs 内嵌的消息目录，locale -> key -> 复数类别 -> 消息
+var catalogs = map[string]map[string]map[string]string{}
+
+func init() {
+	for locale := range pluralRules {
+		data := lo.Must1(catalogFS.ReadFile(path.Join(catalogDir, locale+catalogSuffix)))
+		catalogs[locale] = lo.Must1(ParseCatalog(data))
+	}
+}
+
+// Supported 返回支持的语言，默认语言在最前
+//
+//	return []string
+//	author centonhuang
+//	update 2026-10-18 16:50:20
+func Supported() []string {
+	supported := []string{DefaultLocale()}
+	for _, locale := range []string{LocaleEN, LocaleZH} {
+		if locale != supported[0] {
+			supported = append(supported, locale)
+		}
+	}
+	return supported
+}
+
+// DefaultLocale 返回配置的默认语言，未配置或不支持时为英文
+//
+//	return string
+//	author centonhuang
+//	update 2026-10-18 16:50:26
+func DefaultLocale() string {
+	if locale, ok := Normalize(config.DefaultLocale); ok {
+		return locale
+	}
+	return LocaleEN
+}
+
+// Normalize 将语言标签规范化为支持的语言，如 zh-CN -> zh
+//
+//	param tag string
+//	return locale string
+//	return ok bool 是否为支持的语言
+//	author centonhuang
+//	update 2026-10-18 16:50:32
+func Normalize(tag string) (locale string, ok bool) {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	base, _, _ = strings.Cut(base, "_")
+	_, ok = catalogs[base]
+	return base, ok
+}
+
+// Negotiate 协商请求使用的语言
+//
+//	优先使用登录用户设置的语言，其次按 Accept-Language 选择，均未指定时使用默认语言
+//
+//	param c *fiber.Ctx
+//	return string
+//	author centonhuang
+//	update 2026-10-18 16:50:38
+func Negotiate(c *fiber.Ctx) string {
+	if preferred, ok := c.Locals(constant.CtxKeyLocale).(string); ok {
+		if locale, ok := Normalize(preferred); ok {
+			return locale
+		}
+	}
+	// Accept-Language 为空时 AcceptsLanguages 返回第一个候选，即默认语言
+	if locale := c.AcceptsLanguages(Supported()...); locale != "" {
+		return locale
+	}
+	return DefaultLocale()
+}
+
+// T 翻译消息，缺失时依次回退到默认语言与英文，仍缺失时返回键本身
+//
+//	param locale string
+//	param key string
+//	param args Args
+//	return string
+//	author centonhuang
+//	update 2026-10-18 16:50:44
+func T(locale, key string, args Args) string {
+	message, ok := Translate(locale, key, args)
+	if !ok {
+		return key
+	}
+	return message
+}
+
+// Plural 按数量选择复数形式并翻译消息，数量以 {count} 占位符传入消息
+//
+//	param locale string
+//	param key string
+//	param count int64
+//	param args Args
+//	return string
+//	author centonhuang
+//	update 2026-10-18 16:50:50
+func Plural(locale, key string, count int64, args Args) string {
+	withCount := Args{"count": count}
+	for name, value := range args {
+		withCount[name] = value
+	}
+	return T(locale, key, withCount)
+}
+
+// Translate 翻译消息，缺失时依次回退到默认语言与英文
+//
+//	args 中含有 count 时按其选择复数形式，否则使用 other
+//
+//	param locale string
+//	param key string
+//	param args Args
+//	return message string
+//	return ok bool 所有语言均缺失时为 false
+//	author centonhuang
+//	update 2026-10-18 16:50:56
+func Translate(locale, key string, args Args) (message string, ok bool) {
+	for _, candidate := range []string{locale, DefaultLocale(), LocaleEN} {
+		forms, found := catalogs[candidate][key]
+		if !found {
+			continue
+		}
+		message = forms[pluralForm(candidate, args)]
+		if message == "" {
+			message = forms[pluralOther]
+		}
+		if message == "" {
+			// 未翻译的占位条目
+			continue
+		}
+		return interpolate(message, args), true
+	}
+	return "", false
+}
+
+// Keys 返回语言消息目录中的所有键，按字典序排序
+//
+//	param locale string
+//	return []string
+//	author centonhuang
+//	update 2026-10-18 16:51:02
+func Keys(locale string) []string {
+	keys := lo.Keys(catalogs[locale])
+	slices.Sort(keys)
+	return keys
+}
+
+// ParseCatalog 解析消息目录文件，字符串值视为 other 形式
+//
+//	param data []byte
+//	return map[string]map[string]string key -> 复数类别 -> 消息
+//	return error
+//	author centonhuang
+//	update 2026-10-18 16:51:08
+func ParseCatalog(data []byte) (map[string]map[string]string, error) {
+	var raw map[string]interface{}
+	if err := sonic.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	catalog := make(map[string]map[string]string, len(raw))
+	for key, value := range raw {
+		switch v := value.(type) {
+		case string:
+			catalog[key] = map[string]string{pluralOther: v}
+		case map[string]interface{}:
+			forms := make(map[string]string, len(v))
+			for form, message := range v {
+				text, ok := message.(string)
+				if !ok {
+					return nil, fmt.Errorf("message %q form %q is not a string", key, form)
+				}
+				forms[form] = text
+			}
+			catalog[key] = forms
+		default:
+			return nil, fmt.Errorf("message %q must be a string or an object of plural forms", key)
+		}
+	}
+	return catalog, nil
+}
+
+// pluralForm 按 args 中的 count 选择复数类别
+func pluralForm(locale string, args Args) string {
+	rules, ok := pluralRules[locale]
+	if !ok {
+		return pluralOther
+	}
+
+	var count float64
+	switch v := args["count"].(type) {
+	case int:
+		count = float64(v)
+	case int64:
+		count = float64(v)
+	case uint:
+		count = float64(v)
+	case uint64:
+		count = float64(v)
+	case float64:
+		count = v
+	default:
+		return pluralOther
+	}
+	return strings.ToLower(rules.CardinalPluralRule(count, 0).String())
+}
+
+// interpolate 替换消息中的 {name} 占位符，未传入的占位符保持原样
+func interpolate(message string, args Args) string {
+	if len(args) == 0 || !strings.Contains(message, "{") {
+		return message
+	}
+	pairs := make([]string, 0, 2*len(args))
+	for name, value := range args {
+		pairs = append(pairs, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
@@ -0,0 +1,206 @@
+// Package lifecycle 进程生命周期管理
+//
+//	各资源以 Hook 的形式注册启动与停止逻辑，按注册顺序启动、按相反顺序停止，
+//	停止阶段所有 Hook 共享同一个截止时间，超时后剩余的 Hook 收到已取消的上下文。
+//
+//	update 2026-10-18 17:40:02
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"go.uber.org/zap"
+)
+
+// Hook 资源的启动与停止逻辑，OnStart 与 OnStop 均可为空
+//
+//	author centonhuang
+//	update 2026-10-18 17:40:08
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// Manager 生命周期管理器
+//
+//	author centonhuang
+//	update 2026-10-18 20:00:02
+type Manager struct {
+	hooks   []Hook
+	started int
+	timeout time.Duration
+
+	fatal    chan error
+	signals  chan os.Signal
+	stopOnce sync.Once
+	stopErr  error
+}
+
+// NewManager 创建生命周期管理器
+//
+//	param timeout time.Duration 停止阶段的总截止时间
+//	return *Manager
+//	author centonhuang
+//	update 2026-10-18 17:40:20
+func NewManager(timeout time.Duration) *Manager {
+	return &Manager{
+		timeout: timeout,
+		fatal:   make(chan error, 1),
+	}
+}
+
+// Append 注册 Hook，需在 Start 之前调用
+//
+//	receiver m *Manager
+//	param hooks ...Hook
+//	author centonhuang
+//	update 2026-10-18 17:40:26
+func (m *Manager) Append(hooks ...Hook) {
+	m.hooks = append(m.hooks, hooks...)
+}
+
+// Start 按注册顺序启动，某个 Hook 启动失败时停止已启动的 Hook 并返回错误
+//
+//	资源的初始化函数以 panic 报告失败，启动时将其转换为错误，保证已启动的资源能被关闭；
+//	经 Run 启动时，启动期间收到退出信号会跳过剩余的 Hook，由 Wait 立即返回并停止已启动的 Hook
+//
+//	receiver m *Manager
+//	param ctx context.Context
+//	return error
+//	author centonhuang
+//	update 2026-10-18 20:00:08
+func (m *Manager) Start(ctx context.Context) error {
+	for _, hook := range m.hooks {
+		select {
+		case sig := <-m.signals:
+			logger.Logger().Info("[Lifecycle] Received signal during startup, skipping remaining hooks", zap.String("signal", sig.String()), zap.String("next", hook.Name))
+			// 放回信号交给 Wait 处理，通道刚被读空，不会阻塞
+			m.signals <- sig
+			return nil
+		default:
+		}
+
+		if hook.OnStart != nil {
+			if err := startHook(ctx, hook); err != nil {
+				logger.Logger().Error("[Lifecycle] Failed to start", zap.String("hook", hook.Name), zap.Error(err))
+				return errors.Join(fmt.Errorf("start %s: %w", hook.Name, err), m.Stop())
+			}
+		}
+		m.started++
+		logger.Logger().Info("[Lifecycle] Started", zap.String("hook", hook.Name))
+	}
+	return nil
+}
+
+func startHook(ctx context.Context, hook Hook) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return hook.OnStart(ctx)
+}
+
+// Fail 报告运行期间的致命错误，Wait 收到后返回并触发停止，只保留第一个错误
+//
+//	receiver m *Manager
+//	param name string
+//	param err error
+//	author centonhuang
+//	update 2026-10-18 17:40:38
+func (m *Manager) Fail(name string, err error) {
+	select {
+	case m.fatal <- fmt.Errorf("%s: %w", name, err):
+	default:
+	}
+}
+
+// Wait 阻塞直到收到 SIGINT / SIGTERM、ctx 结束或 Fail 报告致命错误
+//
+//	receiver m *Manager
+//	param ctx context.Context
+//	return error 致命错误，正常收到信号时为 nil
+//	author centonhuang
+//	update 2026-10-18 20:00:14
+func (m *Manager) Wait(ctx context.Context) error {
+	signals := m.signals
+	if signals == nil {
+		signals = make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		defer signal.Stop(signals)
+	}
+
+	select {
+	case sig := <-signals:
+		logger.Logger().Info("[Lifecycle] Received signal, shutting down", zap.String("signal", sig.String()), zap.Duration("timeout", m.timeout))
+		return nil
+	case <-ctx.Done():
+		logger.Logger().Info("[Lifecycle] Context done, shutting down", zap.Duration("timeout", m.timeout))
+		return nil
+	case err := <-m.fatal:
+		logger.Logger().Error("[Lifecycle] Fatal error, shutting down", zap.Error(err))
+		return err
+	}
+}
+
+// Stop 按启动的相反顺序停止已启动的 Hook，所有 Hook 共享 timeout 截止时间，重复调用只执行一次
+//
+//	某个 Hook 停止失败不影响后续 Hook，所有错误合并返回
+//
+//	receiver m *Manager
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:40:50
+func (m *Manager) Stop() error {
+	m.stopOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		var errs []error
+		for i := m.started - 1; i >= 0; i-- {
+			hook := m.hooks[i]
+			if hook.OnStop == nil {
+				continue
+			}
+			start := time.Now()
+			if err := hook.OnStop(ctx); err != nil {
+				logger.Logger().Error("[Lifecycle] Failed to stop", zap.String("hook", hook.Name), zap.Duration("cost", time.Since(start)), zap.Error(err))
+				errs = append(errs, fmt.Errorf("stop %s: %w", hook.Name, err))
+				continue
+			}
+			logger.Logger().Info("[Lifecycle] Stopped", zap.String("hook", hook.Name), zap.Duration("cost", time.Since(start)))
+		}
+		m.stopErr = errors.Join(errs...)
+	})
+	return m.stopErr
+}
+
+// Run 启动、等待退出信号并停止
+//
+//	信号在启动前注册，启动期间收到的信号同样触发优雅停止，而不是按默认行为直接结束进程
+//
+//	receiver m *Manager
+//	param ctx context.Context
+//	return error
+//	author centonhuang
+//	update 2026-10-18 20:00:20
+func (m *Manager) Run(ctx context.Context) error {
+	m.signals = make(chan os.Signal, 1)
+	signal.Notify(m.signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(m.signals)
+
+	if err := m.Start(ctx); err != nil {
+		return err
+	}
+	fatalErr := m.Wait(ctx)
+	return errors.Join(fatalErr, m.Stop())
+}
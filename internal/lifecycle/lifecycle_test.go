@@ -0,0 +1,56 @@
+package lifecycle
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunHandlesSignalDuringStartup(t *testing.T) {
+	var events []string
+	record := func(event string) func(context.Context) error {
+		return func(context.Context) error {
+			events = append(events, event)
+			return nil
+		}
+	}
+
+	m := NewManager(time.Second)
+	m.Append(
+		Hook{Name: "first", OnStart: record("start first"), OnStop: record("stop first")},
+		Hook{Name: "signal", OnStart: func(context.Context) error {
+			events = append(events, "start signal")
+			// 启动期间收到信号：未提前注册时会按默认行为直接结束测试进程
+			if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+				return err
+			}
+			time.Sleep(100 * time.Millisecond)
+			return nil
+		}, OnStop: record("stop signal")},
+		Hook{Name: "last", OnStart: record("start last"), OnStop: record("stop last")},
+	)
+
+	done := make(chan error, 1)
+	go func() { done <- m.Run(context.Background()) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run did not stop after the signal")
+	}
+
+	want := []string{"start first", "start signal", "stop signal", "stop first"}
+	if len(events) != len(want) {
+		t.Fatalf("events = %v, want %v", events, want)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Fatalf("events = %v, want %v", events, want)
+		}
+	}
+}
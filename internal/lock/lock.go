@@ -0,0 +1,359 @@
+// Package lock 分布式锁
+//
+//	支持阻塞获取与超时、后台续期以及单调递增的 fencing token，
+//	Redis 未配置时退化为进程内锁。
+//
+//	update 2026-10-18 15:50:02
+package lock
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/cache"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTTL           = 30 * time.Second
+	defaultRetryInterval = 100 * time.Millisecond
+)
+
+var (
+	// ErrNotAcquired 等待超时仍未获得锁
+	//
+	//	update 2026-10-18 15:50:08
+	ErrNotAcquired = errors.New("lock not acquired")
+
+	// ErrNotHeld 锁已过期或被他人持有
+	//
+	//	update 2026-10-18 15:50:14
+	ErrNotHeld = errors.New("lock not held")
+)
+
+// backend 锁的存储后端
+//
+//	author centonhuang
+//	update 2026-10-18 15:50:20
+type backend interface {
+	// acquire 键不存在时写入 value 并返回新的 fencing token，键已存在时 ok 为 false
+	acquire(ctx context.Context, key, value string, ttl time.Duration) (token uint64, ok bool, err error)
+	// refresh 仅当键的值为 value 时重置过期时间
+	refresh(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
+	// release 仅当键的值为 value 时删除
+	release(ctx context.Context, key, value string) (bool, error)
+}
+
+// Locker 分布式锁
+//
+//	author centonhuang
+//	update 2026-10-18 15:50:26
+type Locker struct {
+	backend backend
+}
+
+// options 获取锁的参数
+type options struct {
+	ttl           time.Duration
+	wait          time.Duration
+	retryInterval time.Duration
+	renew         bool
+}
+
+// Option 获取锁的参数
+//
+//	author centonhuang
+//	update 2026-10-18 15:50:32
+type Option func(*options)
+
+// WithTTL 锁的租期，默认30秒
+//
+//	param ttl time.Duration
+//	return Option
+//	author centonhuang
+//	update 2026-10-18 15:50:38
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// WithWait 锁被占用时最长等待时间，默认不等待
+//
+//	param wait time.Duration
+//	return Option
+//	author centonhuang
+//	update 2026-10-18 15:50:44
+func WithWait(wait time.Duration) Option {
+	return func(o *options) {
+		o.wait = wait
+	}
+}
+
+// WithRetryInterval 等待期间的重试间隔，默认100毫秒，实际间隔带随机抖动
+//
+//	param interval time.Duration
+//	return Option
+//	author centonhuang
+//	update 2026-10-18 15:50:50
+func WithRetryInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.retryInterval = interval
+	}
+}
+
+// WithoutRenewal 关闭后台续期，锁在 ttl 后自动过期
+//
+//	return Option
+//	author centonhuang
+//	update 2026-10-18 15:50:56
+func WithoutRenewal() Option {
+	return func(o *options) {
+		o.renew = false
+	}
+}
+
+var (
+	defaultLocker     *Locker
+	defaultLockerOnce sync.Once
+)
+
+// Default 获取默认的锁，使用 cache 中的Redis客户端，内存模式下为进程内锁
+//
+//	需在 cache.InitCache 之后调用
+//
+//	return *Locker
+//	author centonhuang
+//	update 2026-10-18 15:51:02
+func Default() *Locker {
+	defaultLockerOnce.Do(func() {
+		if cache.IsMemoryMode() {
+			defaultLocker = NewMemoryLocker()
+			return
+		}
+		defaultLocker = NewRedisLocker(cache.GetRedisClient())
+	})
+	return defaultLocker
+}
+
+// Acquire 使用默认的锁获取 key
+//
+//	param ctx context.Context
+//	param key string
+//	param opts ...Option
+//	return *Lock
+//	return error
+//	author centonhuang
+//	update 2026-10-18 15:51:08
+func Acquire(ctx context.Context, key string, opts ...Option) (*Lock, error) {
+	return Default().Acquire(ctx, key, opts...)
+}
+
+// Acquire 获取锁，被占用时按 WithWait 等待，超时返回 ErrNotAcquired
+//
+//	获得的锁默认在后台以 ttl/3 的间隔续期，直到 Release 或续期失败
+//
+//	receiver l *Locker
+//	param ctx context.Context
+//	param key string
+//	param opts ...Option
+//	return *Lock
+//	return error
+//	author centonhuang
+//	update 2026-10-18 15:51:14
+func (l *Locker) Acquire(ctx context.Context, key string, opts ...Option) (*Lock, error) {
+	o := &options{ttl: defaultTTL, retryInterval: defaultRetryInterval, renew: true}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.ttl <= 0 {
+		o.ttl = defaultTTL
+	}
+	if o.retryInterval <= 0 {
+		o.retryInterval = defaultRetryInterval
+	}
+
+	value := uuid.NewString()
+	deadline := time.Now().Add(o.wait)
+	for {
+		token, ok, err := l.backend.acquire(ctx, key, value, o.ttl)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return newLock(l.backend, key, value, token, o), nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, ErrNotAcquired
+		}
+
+		// 随机抖动避免多个等待者同时重试
+		interval := min(remaining, o.retryInterval/2+rand.N(o.retryInterval))
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Lock 已获得的锁
+//
+//	author centonhuang
+//	update 2026-10-18 15:51:20
+type Lock struct {
+	backend backend
+	key     string
+	value   string
+	token   uint64
+	ttl     time.Duration
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	lost     chan struct{}
+	lostOnce sync.Once
+	done     chan struct{}
+}
+
+func newLock(backend backend, key, value string, token uint64, o *options) *Lock {
+	l := &Lock{
+		backend: backend,
+		key:     key,
+		value:   value,
+		token:   token,
+		ttl:     o.ttl,
+		stop:    make(chan struct{}),
+		lost:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if o.renew {
+		go l.renew()
+	} else {
+		close(l.done)
+	}
+	return l
+}
+
+// Key 锁的键
+//
+//	receiver l *Lock
+//	return string
+//	author centonhuang
+//	update 2026-10-18 15:51:26
+func (l *Lock) Key() string {
+	return l.key
+}
+
+// Token fencing token，同一个键每次获得锁时单调递增
+//
+//	写入受保护的资源时携带该值，资源方拒绝小于已见过的最大值的写入，
+//	以防止暂停后锁已过期的持有者覆盖新持有者的写入
+//
+//	receiver l *Lock
+//	return uint64
+//	author centonhuang
+//	update 2026-10-18 15:51:32
+func (l *Lock) Token() uint64 {
+	return l.token
+}
+
+// Lost 续期失败、锁可能已被他人获得时关闭
+//
+//	receiver l *Lock
+//	return <-chan struct{}
+//	author centonhuang
+//	update 2026-10-18 15:51:38
+func (l *Lock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// Refresh 手动续期
+//
+//	receiver l *Lock
+//	param ctx context.Context
+//	param ttl time.Duration
+//	return error 锁已丢失时返回 ErrNotHeld
+//	author centonhuang
+//	update 2026-10-18 15:51:44
+func (l *Lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	ok, err := l.backend.refresh(ctx, l.key, l.value, ttl)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		l.markLost()
+		return ErrNotHeld
+	}
+	return nil
+}
+
+// Release 停止续期并释放锁
+//
+//	receiver l *Lock
+//	param ctx context.Context
+//	return error 锁在释放前已丢失时返回 ErrNotHeld
+//	author centonhuang
+//	update 2026-10-18 15:51:50
+func (l *Lock) Release(ctx context.Context) error {
+	l.stopOnce.Do(func() { close(l.stop) })
+	<-l.done
+
+	ok, err := l.backend.release(ctx, l.key, l.value)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		l.markLost()
+		return ErrNotHeld
+	}
+	return nil
+}
+
+// renew 以 ttl/3 的间隔续期，持有者确认变化或续期失败超过 ttl 时标记锁丢失
+func (l *Lock) renew() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+
+	lastRenewed := time.Now()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), l.ttl/3)
+		ok, err := l.backend.refresh(ctx, l.key, l.value, l.ttl)
+		cancel()
+
+		switch {
+		case err == nil && ok:
+			lastRenewed = time.Now()
+		case err == nil:
+			logger.Logger().Warn("[Lock] lock lost", zap.String("key", l.key), zap.Uint64("token", l.token))
+			l.markLost()
+			return
+		default:
+			logger.Logger().Warn("[Lock] failed to renew lock", zap.String("key", l.key), zap.Error(err))
+			if time.Since(lastRenewed) >= l.ttl {
+				l.markLost()
+				return
+			}
+		}
+	}
+}
+
+func (l *Lock) markLost() {
+	l.lostOnce.Do(func() { close(l.lost) })
+}
@@ -0,0 +1,68 @@
+package lock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	value    string
+	expireAt time.Time
+}
+
+type memoryBackend struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	tokens  map[string]uint64
+}
+
+// NewMemoryLocker 创建进程内的锁，仅在单实例部署或开发环境中使用
+//
+//	return *Locker
+//	author centonhuang
+//	update 2026-10-18 15:52:08
+func NewMemoryLocker() *Locker {
+	return &Locker{backend: &memoryBackend{
+		entries: map[string]memoryEntry{},
+		tokens:  map[string]uint64{},
+	}}
+}
+
+func (b *memoryBackend) acquire(_ context.Context, key, value string, ttl time.Duration) (uint64, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if entry, ok := b.entries[key]; ok && time.Now().Before(entry.expireAt) {
+		return 0, false, nil
+	}
+
+	b.entries[key] = memoryEntry{value: value, expireAt: time.Now().Add(ttl)}
+	b.tokens[key]++
+	return b.tokens[key], true, nil
+}
+
+func (b *memoryBackend) refresh(_ context.Context, key, value string, ttl time.Duration) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry, ok := b.entries[key]
+	if !ok || entry.value != value || time.Now().After(entry.expireAt) {
+		return false, nil
+	}
+	entry.expireAt = time.Now().Add(ttl)
+	b.entries[key] = entry
+	return true, nil
+}
+
+func (b *memoryBackend) release(_ context.Context, key, value string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry, ok := b.entries[key]
+	if !ok || entry.value != value {
+		return false, nil
+	}
+	delete(b.entries, key)
+	return time.Now().Before(entry.expireAt), nil
+}
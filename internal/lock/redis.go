@@ -0,0 +1,74 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hcd233/go-backend-tmpl/internal/resource/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+// 锁键与 fencing 计数器使用相同的 hash tag，保证集群模式下位于同一个槽
+var (
+	acquireScript = redis.NewScript(`
+if redis.call("set", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return redis.call("incr", KEYS[2])
+end
+return 0
+`)
+
+	refreshScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("pexpire", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+	releaseScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0
+`)
+)
+
+type redisBackend struct {
+	rdb redis.UniversalClient
+}
+
+// NewRedisLocker 创建基于Redis的锁
+//
+//	param rdb redis.UniversalClient
+//	return *Locker
+//	author centonhuang
+//	update 2026-10-18 15:52:02
+func NewRedisLocker(rdb redis.UniversalClient) *Locker {
+	return &Locker{backend: &redisBackend{rdb: rdb}}
+}
+
+func (b *redisBackend) acquire(ctx context.Context, key, value string, ttl time.Duration) (uint64, bool, error) {
+	lockKey, fenceKey := redisKeys(key)
+	token, err := acquireScript.Run(ctx, b.rdb, []string{lockKey, fenceKey}, value, ttl.Milliseconds()).Uint64()
+	if err != nil {
+		return 0, false, err
+	}
+	return token, token > 0, nil
+}
+
+func (b *redisBackend) refresh(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	lockKey, _ := redisKeys(key)
+	n, err := refreshScript.Run(ctx, b.rdb, []string{lockKey}, value, ttl.Milliseconds()).Int64()
+	return n > 0, err
+}
+
+func (b *redisBackend) release(ctx context.Context, key, value string) (bool, error) {
+	lockKey, _ := redisKeys(key)
+	n, err := releaseScript.Run(ctx, b.rdb, []string{lockKey}, value).Int64()
+	return n > 0, err
+}
+
+func redisKeys(key string) (lockKey, fenceKey string) {
+	lockKey = cache.Key(fmt.Sprintf("lock:{%s}", key))
+	return lockKey, lockKey + ":fence"
+}
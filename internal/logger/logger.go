@@ -10,6 +10,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/hcd233/go-backend-tmpl/internal/config"
 	"github.com/hcd233/go-backend-tmpl/internal/constant"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -50,16 +51,18 @@ func Logger() *zap.Logger {
 	return defaultLogger
 }
 
-// WithCtx 根据上下文获取日志
+// WithCtx 根据上下文获取日志，非请求上下文（如定时任务）中取 OpenTelemetry Span 的链路ID
 //
 //	param ctx context.Context
 //	return *zap.Logger
 //	author centonhuang
-//	update 2025-08-22 14:29:58
+//	update 2026-10-18 17:06:02
 func WithCtx(ctx context.Context) *zap.Logger {
 	logger := defaultLogger
 	if traceID := ctx.Value(constant.CtxKeyTraceID); traceID != nil {
 		logger = logger.With(zap.String(constant.CtxKeyTraceID, traceID.(string)))
+	} else if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		logger = logger.With(zap.String(constant.CtxKeyTraceID, spanContext.TraceID().String()))
 	}
 	if userID := ctx.Value(constant.CtxKeyUserID); userID != nil {
 		logger = logger.With(zap.Uint(constant.CtxKeyUserID, userID.(uint)))
@@ -101,10 +104,11 @@ func init() {
 		logLevelFatal:  zap.NewAtomicLevelAt(zap.FatalLevel),
 	}
 
-	logLevel, ok := zapLevelMapping[strings.ToUpper(config.LogLevel)]
-	if !ok {
-		logLevel = zap.NewAtomicLevelAt(zap.InfoLevel)
-	}
+	// 单独创建级别，热更新时不影响错误日志文件的级别
+	logLevel := zap.NewAtomicLevelAt(parseLevel(config.Current().Log.Level))
+	config.OnReload(func(config.ReloadEvent) {
+		logLevel.SetLevel(parseLevel(config.Current().Log.Level))
+	})
 
 	// general logger
 	logFileWriter := zapcore.AddSync(&lumberjack.Logger{
@@ -193,3 +197,12 @@ func init() {
 
 	defaultLogger = zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1), zap.AddStacktrace(zapLevelMapping[logLevelPanic]))
 }
+
+// parseLevel 解析日志级别，不区分大小写，无法解析时为 info
+func parseLevel(level string) zapcore.Level {
+	l, err := zapcore.ParseLevel(strings.ToLower(level))
+	if err != nil {
+		return zapcore.InfoLevel
+	}
+	return l
+}
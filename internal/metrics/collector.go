@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"github.com/hcd233/go-backend-tmpl/internal/resource/cache"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/dao"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	redisPoolHitsDesc     = prometheus.NewDesc("redis_pool_hits_total", "Number of times a free connection was found in the Redis pool.", nil, nil)
+	redisPoolMissesDesc   = prometheus.NewDesc("redis_pool_misses_total", "Number of times a free connection was not found in the Redis pool.", nil, nil)
+	redisPoolTimeoutsDesc = prometheus.NewDesc("redis_pool_timeouts_total", "Number of times a wait timeout occurred in the Redis pool.", nil, nil)
+	redisPoolStaleDesc    = prometheus.NewDesc("redis_pool_stale_connections_total", "Number of stale connections removed from the Redis pool.", nil, nil)
+	redisPoolConnsDesc    = prometheus.NewDesc("redis_pool_connections", "Number of connections in the Redis pool by state.", []string{"state"}, nil)
+
+	cacheRequestsDesc    = prometheus.NewDesc("cache_requests_total", "Number of cache namespace lookups by namespace and result.", []string{"namespace", "result"}, nil)
+	daoCacheRequestsDesc = prometheus.NewDesc("dao_cache_requests_total", "Number of cached DAO lookups by namespace and result.", []string{"namespace", "result"}, nil)
+)
+
+// redisPoolCollector 抓取时读取 Redis 连接池统计
+//
+//	author centonhuang
+//	update 2026-10-18 17:31:02
+type redisPoolCollector struct {
+	rdb redis.UniversalClient
+}
+
+// Describe 实现 prometheus.Collector
+//
+//	receiver c redisPoolCollector
+//	param ch chan<- *prometheus.Desc
+//	author centonhuang
+//	update 2026-10-18 17:31:08
+func (c redisPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- redisPoolHitsDesc
+	ch <- redisPoolMissesDesc
+	ch <- redisPoolTimeoutsDesc
+	ch <- redisPoolStaleDesc
+	ch <- redisPoolConnsDesc
+}
+
+// Collect 实现 prometheus.Collector
+//
+//	receiver c redisPoolCollector
+//	param ch chan<- prometheus.Metric
+//	author centonhuang
+//	update 2026-10-18 17:31:14
+func (c redisPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.rdb.PoolStats()
+	ch <- prometheus.MustNewConstMetric(redisPoolHitsDesc, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(redisPoolMissesDesc, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(redisPoolTimeoutsDesc, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(redisPoolStaleDesc, prometheus.CounterValue, float64(stats.StaleConns))
+	ch <- prometheus.MustNewConstMetric(redisPoolConnsDesc, prometheus.GaugeValue, float64(stats.TotalConns-stats.IdleConns), "in_use")
+	ch <- prometheus.MustNewConstMetric(redisPoolConnsDesc, prometheus.GaugeValue, float64(stats.IdleConns), "idle")
+}
+
+// cacheCollector 抓取时读取缓存命名空间与缓存DAO的命中统计
+//
+//	author centonhuang
+//	update 2026-10-18 17:31:20
+type cacheCollector struct{}
+
+// Describe 实现 prometheus.Collector
+//
+//	receiver cacheCollector
+//	param ch chan<- *prometheus.Desc
+//	author centonhuang
+//	update 2026-10-18 17:31:26
+func (cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cacheRequestsDesc
+	ch <- daoCacheRequestsDesc
+}
+
+// Collect 实现 prometheus.Collector
+//
+//	receiver cacheCollector
+//	param ch chan<- prometheus.Metric
+//	author centonhuang
+//	update 2026-10-18 17:31:32
+func (cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	for name, stats := range cache.GetStats() {
+		for result, value := range map[string]uint64{
+			"local_hit":  stats.LocalHits,
+			"remote_hit": stats.RemoteHits,
+			"miss":       stats.Misses,
+			"error":      stats.Errors,
+		} {
+			ch <- prometheus.MustNewConstMetric(cacheRequestsDesc, prometheus.CounterValue, float64(value), name, result)
+		}
+	}
+
+	for name, stats := range dao.GetCacheStats() {
+		for result, value := range map[string]uint64{
+			"hit":          stats.Hits,
+			"negative_hit": stats.NegativeHits,
+			"miss":         stats.Misses,
+			"error":        stats.Errors,
+		} {
+			ch <- prometheus.MustNewConstMetric(daoCacheRequestsDesc, prometheus.CounterValue, float64(value), name, result)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Package metrics Prometheus 指标
+//
+//	使用独立的 Registry，只暴露本服务注册的指标与 Go 运行时、进程指标。
+//	请求指标由 MetricsMiddleware 记录，按路由模板而非实际路径打标签以控制基数；
+//	数据库连接池、Redis 连接池与缓存命中统计在抓取时读取，不需要额外的定时任务。
+//
+//	update 2026-10-18 17:30:02
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/hcd233/go-backend-tmpl/internal/lifecycle"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/cache"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// sizeBuckets 请求与响应体大小的分桶，100B 至 100MB
+var sizeBuckets = prometheus.ExponentialBuckets(100, 10, 7)
+
+var registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequestsTotal 请求数，route 为路由模板
+	//
+	//	update 2026-10-18 17:30:08
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration 请求耗时
+	//
+	//	update 2026-10-18 17:30:08
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds by method, route template and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestSize 请求体大小
+	//
+	//	update 2026-10-18 17:30:08
+	HTTPRequestSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_size_bytes",
+		Help:    "HTTP request body size in bytes by method, route template and status code.",
+		Buckets: sizeBuckets,
+	}, []string{"method", "route", "status"})
+
+	// HTTPResponseSize 响应体大小，启用压缩时为压缩后的大小
+	//
+	//	update 2026-10-18 17:30:08
+	HTTPResponseSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_response_size_bytes",
+		Help:    "HTTP response body size in bytes by method, route template and status code.",
+		Buckets: sizeBuckets,
+	}, []string{"method", "route", "status"})
+
+	// RateLimitRejectionsTotal 限频拒绝次数，reason 为 rate 或 quota
+	//
+	//	update 2026-10-18 17:30:08
+	RateLimitRejectionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ratelimit_rejections_total",
+		Help: "Total number of requests rejected by rate limiters by service and reason.",
+	}, []string{"service", "reason"})
+
+	// LockAcquisitionsTotal 请求级分布式锁的获取结果，result 为 acquired、contended 或 error
+	//
+	//	update 2026-10-18 17:30:08
+	LockAcquisitionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "lock_acquisitions_total",
+		Help: "Total number of distributed lock acquisitions by service and result.",
+	}, []string{"service", "result"})
+
+	// CronJobRunsTotal 定时任务执行次数，result 为 success、skipped 或 error
+	//
+	//	update 2026-10-18 17:30:08
+	CronJobRunsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cron_job_runs_total",
+		Help: "Total number of cron job runs by job and result.",
+	}, []string{"job", "result"})
+
+	// CronJobDuration 定时任务耗时
+	//
+	//	update 2026-10-18 17:30:08
+	CronJobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cron_job_duration_seconds",
+		Help:    "Cron job run duration in seconds by job and result.",
+		Buckets: prometheus.ExponentialBuckets(0.01, 4, 10),
+	}, []string{"job", "result"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestsTotal,
+		HTTPRequestDuration,
+		HTTPRequestSize,
+		HTTPResponseSize,
+		RateLimitRejectionsTotal,
+		LockAcquisitionsTotal,
+		CronJobRunsTotal,
+		CronJobDuration,
+		cacheCollector{},
+	)
+}
+
+// InitMetrics 注册依赖外部资源的指标，需在 InitDatabase 与 InitCache 之后调用
+//
+//	author centonhuang
+//	update 2026-10-18 17:30:14
+func InitMetrics() {
+	sqlDB := lo.Must1(database.GetDBInstance(context.Background()).DB())
+	registry.MustRegister(collectors.NewDBStatsCollector(sqlDB, "primary"))
+
+	if rdb := cache.GetRedisClient(); rdb != nil {
+		registry.MustRegister(redisPoolCollector{rdb: rdb})
+	}
+
+	logger.Logger().Info("[Metrics] Prometheus metrics registered")
+}
+
+// LifecycleHook 指标的启动逻辑，需在数据库与缓存之后启动
+//
+//	return lifecycle.Hook
+//	author centonhuang
+//	update 2026-10-18 17:44:08
+func LifecycleHook() lifecycle.Hook {
+	return lifecycle.Hook{
+		Name: "metrics",
+		OnStart: func(context.Context) error {
+			InitMetrics()
+			return nil
+		},
+	}
+}
+
+// Handler 以 Prometheus 文本格式输出指标
+//
+//	return fiber.Handler
+//	author centonhuang
+//	update 2026-10-18 17:30:20
+func Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		ErrorLog:          zapErrorLogger{},
+		EnableOpenMetrics: true,
+	}))
+}
+
+type zapErrorLogger struct{}
+
+func (zapErrorLogger) Println(v ...interface{}) {
+	logger.Logger().Error("[Metrics] Failed to serve metrics", zap.String("error", fmt.Sprint(v...)))
+}
@@ -1,23 +1,30 @@
 package middleware
 
 import (
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/samber/lo"
 )
 
-// CORSMiddleware 跨域中间件
+// CORSMiddleware 跨域中间件，允许的来源取自 CORS_ALLOW_ORIGINS，随配置文件热更新
 //
-//	return gin.HandlerFunc
+//	return fiber.Handler
 //	author centonhuang
-//	update 2024-09-16 04:07:30
+//	update 2026-10-18 18:27:02
 func CORSMiddleware() fiber.Handler {
 	return cors.New(cors.Config{
-		AllowOrigins:     "http://localhost:3000",
+		AllowOriginsFunc: func(origin string) bool {
+			return lo.ContainsBy(config.Current().CORS.AllowOrigins, func(allowed string) bool {
+				return strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin)
+			})
+		},
 		AllowMethods:     "GET,POST,PUT,PATCH,DELETE,HEAD,OPTIONS",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,X-Requested-With,X-Trace-Id",
-		ExposeHeaders:    "Content-Length",
+		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,X-Requested-With,X-Trace-Id,X-Tenant-Id,If-Match,Idempotency-Key",
+		ExposeHeaders:    "Content-Length,ETag,Idempotent-Replayed,X-RateLimit-Limit,X-RateLimit-Remaining,X-RateLimit-Reset,Retry-After",
 		AllowCredentials: true,
 		MaxAge:           int(12 * time.Hour.Seconds()),
 	})
@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/bytedance/sonic"
+	"github.com/gofiber/fiber/v2"
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/constant"
+	"github.com/hcd233/go-backend-tmpl/internal/lock"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/protocol"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/cache"
+	"go.uber.org/zap"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotentReplayedHeader  = "Idempotent-Replayed"
+	idempotencyKeyMaxLength   = 255
+	idempotencyNamespaceName  = "idempotency"
+	idempotencyLockKeyPattern = "idempotency:%s"
+)
+
+// idempotencyReplayHeaders 重放时恢复的响应头，其余响应头（如 X-Trace-Id）由本次请求的中间件重新生成
+var idempotencyReplayHeaders = []string{
+	fiber.HeaderContentType,
+	fiber.HeaderContentLanguage,
+	fiber.HeaderETag,
+	fiber.HeaderLocation,
+	fiber.HeaderLastModified,
+}
+
+// idempotencyRecord 首次请求的响应
+//
+//	author centonhuang
+//	update 2026-10-18 16:05:08
+type idempotencyRecord struct {
+	Fingerprint string            `json:"fingerprint"`
+	Status      int               `json:"status"`
+	Headers     map[string]string `json:"headers"`
+	Body        []byte            `json:"body"`
+}
+
+// IdempotencyMiddleware 幂等中间件，按用户与 Idempotency-Key 请求头保存首次请求的响应
+//
+//	相同的键与请求再次到达时直接重放保存的响应，并设置 Idempotent-Replayed 响应头；
+//	首次请求仍在处理时返回409，相同的键用于不同的请求时返回422。
+//	5xx、409与429响应不保存，客户端可以使用相同的键重试。
+//	未携带请求头的请求直接放行，需放在 JwtMiddleware 之后、限频中间件之前，重放与重试不消耗限频配额。
+//	处理期间持有的锁租期为读写超时之和并在后台续期，保存响应前确认仍持有锁，锁已丢失时不保存。
+//
+//	param serviceName string
+//	return fiber.Handler
+//	author centonhuang
+//	update 2026-10-18 20:14:02
+func IdempotencyMiddleware(serviceName string) fiber.Handler {
+	ns := cache.NewNamespace(idempotencyNamespaceName, config.IdempotencyTTL)
+
+	return func(c *fiber.Ctx) error {
+		idempotencyKey := c.Get(idempotencyKeyHeader)
+		if idempotencyKey == "" {
+			return c.Next()
+		}
+		if len(idempotencyKey) > idempotencyKeyMaxLength {
+			logger.WithFCtx(c).Info("[IdempotencyMiddleware] idempotency key too long", zap.Int("length", len(idempotencyKey)))
+			return protocol.ErrBadRequest.WithMessageKey("error.idempotency.keyTooLong", map[string]interface{}{"count": idempotencyKeyMaxLength})
+		}
+
+		ctx := c.UserContext()
+		recordKey := fmt.Sprintf("%s:%v:%s", serviceName, c.Locals(constant.CtxKeyUserID), idempotencyKey)
+		fingerprint := idempotencyFingerprint(c)
+
+		replayed, err := replayIdempotencyRecord(c, ns, recordKey, fingerprint)
+		if replayed || err != nil {
+			return err
+		}
+
+		// 锁的租期与请求的超时时间一致，处理耗时更长时由后台续期保持持有
+		lockTTL := config.ReadTimeout + config.WriteTimeout
+		l, err := lock.Acquire(ctx, fmt.Sprintf(idempotencyLockKeyPattern, recordKey), lock.WithTTL(lockTTL))
+		if err != nil {
+			if errors.Is(err, lock.ErrNotAcquired) {
+				logger.WithFCtx(c).Info("[IdempotencyMiddleware] request in flight", zap.String("recordKey", recordKey))
+				return protocol.ErrConflict.WithMessageKey("error.idempotency.inProgress", nil)
+			}
+			logger.WithFCtx(c).Error("[IdempotencyMiddleware] failed to get lock", zap.String("recordKey", recordKey), zap.Error(err))
+			return protocol.ErrInternalError.WithCause(err)
+		}
+		defer func() {
+			if releaseErr := l.Release(ctx); releaseErr != nil {
+				logger.WithFCtx(c).Error("[IdempotencyMiddleware] failed to release lock", zap.String("recordKey", recordKey), zap.Error(releaseErr))
+			}
+		}()
+
+		// 获得锁前首次请求可能刚好处理完成
+		replayed, err = replayIdempotencyRecord(c, ns, recordKey, fingerprint)
+		if replayed || err != nil {
+			return err
+		}
+
+		// 在此处生成错误响应，使处理器返回的4xx错误同样被保存与重放
+		if err = c.Next(); err != nil {
+			if err = c.App().ErrorHandler(c, err); err != nil {
+				return err
+			}
+		}
+
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError || status == fiber.StatusConflict || status == fiber.StatusTooManyRequests {
+			return nil
+		}
+
+		// 保存前确认仍持有锁：锁在处理期间丢失时，相同的键可能已被另一个请求处理并保存，不再覆盖
+		if refreshErr := l.Refresh(ctx, lockTTL); refreshErr != nil {
+			if errors.Is(refreshErr, lock.ErrNotHeld) {
+				logger.WithFCtx(c).Warn("[IdempotencyMiddleware] lock lost before saving record, record not saved", zap.String("recordKey", recordKey), zap.Uint64("token", l.Token()))
+			} else {
+				logger.WithFCtx(c).Error("[IdempotencyMiddleware] failed to refresh lock, record not saved", zap.String("recordKey", recordKey), zap.Error(refreshErr))
+			}
+			return nil
+		}
+
+		record := &idempotencyRecord{
+			Fingerprint: fingerprint,
+			Status:      status,
+			Headers:     map[string]string{},
+			Body:        append([]byte(nil), c.Response().Body()...),
+		}
+		for _, header := range idempotencyReplayHeaders {
+			if value := c.GetRespHeader(header); value != "" {
+				record.Headers[header] = value
+			}
+		}
+
+		value, err := sonic.Marshal(record)
+		if err != nil {
+			logger.WithFCtx(c).Error("[IdempotencyMiddleware] failed to marshal record", zap.String("recordKey", recordKey), zap.Error(err))
+			return nil
+		}
+		if err := ns.Set(ctx, recordKey, value, 0); err != nil {
+			// 响应已经生成，保存失败只记录日志
+			logger.WithFCtx(c).Error("[IdempotencyMiddleware] failed to save record", zap.String("recordKey", recordKey), zap.Error(err))
+		}
+		return nil
+	}
+}
+
+// replayIdempotencyRecord 存在保存的响应时重放或拒绝请求
+//
+//	param c *fiber.Ctx
+//	param ns *cache.Namespace
+//	param recordKey string
+//	param fingerprint string
+//	return replayed bool 是否已重放保存的响应
+//	return err error 读取失败或相同的键用于不同的请求
+//	author centonhuang
+//	update 2026-10-18 17:46:14
+func replayIdempotencyRecord(c *fiber.Ctx, ns *cache.Namespace, recordKey, fingerprint string) (replayed bool, err error) {
+	value, ok, err := ns.Get(c.UserContext(), recordKey)
+	if err != nil {
+		logger.WithFCtx(c).Error("[IdempotencyMiddleware] failed to get record", zap.String("recordKey", recordKey), zap.Error(err))
+		return false, protocol.ErrInternalError.WithCause(err)
+	}
+	if !ok {
+		return false, nil
+	}
+
+	var record idempotencyRecord
+	if err := sonic.Unmarshal(value, &record); err != nil {
+		logger.WithFCtx(c).Error("[IdempotencyMiddleware] failed to unmarshal record", zap.String("recordKey", recordKey), zap.Error(err))
+		return false, protocol.ErrInternalError.WithCause(err)
+	}
+
+	if record.Fingerprint != fingerprint {
+		logger.WithFCtx(c).Info("[IdempotencyMiddleware] idempotency key reused with different request", zap.String("recordKey", recordKey))
+		return false, protocol.ErrUnprocessableEntity.WithMessageKey("error.idempotency.keyReused", nil)
+	}
+
+	for header, value := range record.Headers {
+		c.Set(header, value)
+	}
+	c.Set(idempotentReplayedHeader, "true")
+	return true, c.Status(record.Status).Send(record.Body)
+}
+
+// idempotencyFingerprint 请求指纹，由方法、路径、查询参数与请求体计算
+//
+//	param c *fiber.Ctx
+//	return string
+//	author centonhuang
+//	update 2026-10-18 16:05:26
+func idempotencyFingerprint(c *fiber.Ctx) string {
+	h := sha256.New()
+	h.Write([]byte(c.Method()))
+	h.Write([]byte{0})
+	h.Write([]byte(c.OriginalURL()))
+	h.Write([]byte{0})
+	h.Write(c.Body())
+	return hex.EncodeToString(h.Sum(nil))
+}
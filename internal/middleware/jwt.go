@@ -13,57 +13,57 @@ import (
 	"github.com/hcd233/go-backend-tmpl/internal/protocol"
 	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
 	"github.com/hcd233/go-backend-tmpl/internal/resource/database/dao"
-	"github.com/hcd233/go-backend-tmpl/internal/util"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
-// JwtMiddleware JWT 中间件
+// JwtMiddleware JWT 中间件，鉴权后将用户信息与所属租户写入 Locals，并将租户写入上下文供DAO做租户隔离
 //
+//	X-Tenant-Id 请求头与用户所属租户不一致时拒绝请求
+//
+//	param dao *dao.UserDAO
+//	param jwtAccessTokenSvc auth.JwtTokenSigner
 //	return fiber.Handler
 //	author centonhuang
-//	update 2024-09-16 05:35:57
-func JwtMiddleware() fiber.Handler {
-	dao := dao.GetUserDAO()
-	jwtAccessTokenSvc := auth.GetJwtAccessTokenSigner()
-
+//	update 2026-10-18 20:16:08
+func JwtMiddleware(dao *dao.UserDAO, jwtAccessTokenSvc auth.JwtTokenSigner) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		db := database.GetDBInstanceFromFiber(c)
 
 		tokenString := c.Get("Authorization")
 		if tokenString == "" {
-			logger.WithFCtx(c).Error("[JwtMiddleware] token is empty")
-			util.SendHTTPResponse(c, nil, protocol.ErrUnauthorized)
-			return c.Status(fiber.StatusUnauthorized).JSON(protocol.HTTPResponse{
-				Error: protocol.ErrUnauthorized.Error(),
-			})
+			logger.WithFCtx(c).Info("[JwtMiddleware] token is empty")
+			return protocol.ErrUnauthorized
 		}
 
 		userID, err := jwtAccessTokenSvc.DecodeToken(tokenString)
 		if err != nil {
-			logger.WithFCtx(c).Error("[JwtMiddleware] failed to decode token", zap.Error(err))
-			util.SendHTTPResponse(c, nil, protocol.ErrUnauthorized)
-			return c.Status(fiber.StatusUnauthorized).JSON(protocol.HTTPResponse{
-				Error: protocol.ErrUnauthorized.Error(),
-			})
+			logger.WithFCtx(c).Info("[JwtMiddleware] failed to decode token", zap.Error(err))
+			return protocol.ErrUnauthorized
 		}
 
-		user, err := dao.GetByID(db, userID, []string{"id", "name", "permission"}, []string{})
+		user, err := dao.GetByID(db, userID, []string{"id", "name", "permission", "locale", "tenant_id"}, []string{})
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				logger.WithFCtx(c).Error("[JwtMiddleware] user not found", zap.Uint("userID", userID))
-				util.SendHTTPResponse(c, nil, protocol.ErrDataNotExists)
-			} else {
-				logger.WithFCtx(c).Error("[JwtMiddleware] failed to get user", zap.Uint("userID", userID), zap.Error(err))
-				util.SendHTTPResponse(c, nil, protocol.ErrInternalError)
+				// 令牌有效但用户已不存在，视为未登录
+				logger.WithFCtx(c).Info("[JwtMiddleware] user not found", zap.Uint("userID", userID))
+				return protocol.ErrUnauthorized
 			}
-			return c.Status(fiber.StatusInternalServerError).JSON(protocol.HTTPResponse{
-				Error: protocol.ErrInternalError.Error(),
-			})
+			logger.WithFCtx(c).Error("[JwtMiddleware] failed to get user", zap.Uint("userID", userID), zap.Error(err))
+			return protocol.ErrInternalError.WithCause(err)
 		}
 		c.Locals(constant.CtxKeyUserID, user.ID)
 		c.Locals(constant.CtxKeyUserName, user.Name)
 		c.Locals(constant.CtxKeyPermission, user.Permission)
+		if user.Locale != "" {
+			c.Locals(constant.CtxKeyLocale, user.Locale)
+		}
+		if user.TenantID != 0 {
+			c.Locals(constant.CtxKeyTenantID, user.TenantID)
+		}
+		if err := bindTenant(c, user.TenantID); err != nil {
+			return err
+		}
 		return c.Next()
 	}
 }
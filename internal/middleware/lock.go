@@ -1,77 +1,57 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/google/uuid"
+	"github.com/hcd233/go-backend-tmpl/internal/constant"
+	"github.com/hcd233/go-backend-tmpl/internal/lock"
 	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/metrics"
 	"github.com/hcd233/go-backend-tmpl/internal/protocol"
-	"github.com/hcd233/go-backend-tmpl/internal/resource/cache"
-	"github.com/hcd233/go-backend-tmpl/internal/util"
 	"go.uber.org/zap"
 )
 
-// RedisLockMiddleware Redis锁中间件
+// RedisLockMiddleware 分布式锁中间件，锁被占用时最多等待 wait，处理期间自动续期
+//
+//	fencing token 写入 constant.CtxKeyLockToken，供后续写入受保护资源时使用
 //
 //	param serviceName string
 //	param key string
-//	param expire time.Duration
+//	param expire time.Duration 锁的租期
+//	param wait time.Duration 锁被占用时的最长等待时间，为0时立即返回429
 //	return fiber.Handler
 //	author centonhuang
-//	update 2025-01-05 15:06:51
-func RedisLockMiddleware(serviceName, key string, expire time.Duration) fiber.Handler {
-	redis := cache.GetRedisClient()
-
+//	update 2026-10-18 17:46:20
+func RedisLockMiddleware(serviceName, key string, expire, wait time.Duration) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		ctx := c.Context()
+		ctx := c.UserContext()
 
 		value := c.Locals(key)
 
 		lockKey := fmt.Sprintf("%s:%s:%v", serviceName, key, value)
-		lockValue := uuid.New().String()
 
-		success, err := redis.SetNX(ctx, lockKey, lockValue, expire).Result()
+		l, err := lock.Acquire(ctx, lockKey, lock.WithTTL(expire), lock.WithWait(wait))
 		if err != nil {
-			logger.WithFCtx(c).Error("[RedisLockMiddleware] failed to get lock", zap.Error(err))
-			util.SendHTTPResponse(c, nil, protocol.ErrInternalError)
-			return c.Status(fiber.StatusInternalServerError).JSON(protocol.HTTPResponse{
-				Error: protocol.ErrInternalError.Error(),
-			})
-		}
-
-		if !success {
-			lockValue, err = redis.Get(ctx, lockKey).Result()
-			if err != nil {
-				logger.WithFCtx(c).Error("[RedisLockMiddleware] failed to get lock info", zap.String("lockKey", lockKey), zap.Error(err))
-				util.SendHTTPResponse(c, nil, protocol.ErrInternalError)
-				return c.Status(fiber.StatusInternalServerError).JSON(protocol.HTTPResponse{
-					Error: protocol.ErrInternalError.Error(),
-				})
+			if errors.Is(err, lock.ErrNotAcquired) {
+				metrics.LockAcquisitionsTotal.WithLabelValues(serviceName, "contended").Inc()
+				logger.WithFCtx(c).Info("[RedisLockMiddleware] resource is locked", zap.String("lockKey", lockKey))
+				return protocol.ErrTooManyRequests
 			}
-			logger.WithFCtx(c).Info("[RedisLockMiddleware] resource is locked", zap.String("lockKey", lockKey), zap.String("lockValue", lockValue))
-			util.SendHTTPResponse(c, nil, protocol.ErrTooManyRequests)
-			return c.Status(fiber.StatusTooManyRequests).JSON(protocol.HTTPResponse{
-				Error: protocol.ErrTooManyRequests.Error(),
-			})
+			metrics.LockAcquisitionsTotal.WithLabelValues(serviceName, "error").Inc()
+			logger.WithFCtx(c).Error("[RedisLockMiddleware] failed to get lock", zap.String("lockKey", lockKey), zap.Error(err))
+			return protocol.ErrInternalError.WithCause(err)
 		}
+		metrics.LockAcquisitionsTotal.WithLabelValues(serviceName, "acquired").Inc()
+		c.Locals(constant.CtxKeyLockToken, l.Token())
 
 		err = c.Next()
 
-		luaScript := `
-			if redis.call("get", KEYS[1]) == ARGV[1] then
-				return redis.call("del", KEYS[1])
-			else
-				return 0
-			end
-		`
-		if err := redis.Eval(ctx, luaScript, []string{lockKey}, lockValue).Err(); err != nil {
-			logger.WithFCtx(c).Error("[RedisLockMiddleware] failed to release lock", zap.String("lockKey", lockKey), zap.Error(err))
-			util.SendHTTPResponse(c, nil, protocol.ErrInternalError)
-			return c.Status(fiber.StatusInternalServerError).JSON(protocol.HTTPResponse{
-				Error: protocol.ErrInternalError.Error(),
-			})
+		if releaseErr := l.Release(ctx); releaseErr != nil {
+			// 处理已经完成，锁丢失只记录日志，不覆盖已写入的响应
+			logger.WithFCtx(c).Error("[RedisLockMiddleware] failed to release lock", zap.String("lockKey", lockKey), zap.Uint64("token", l.Token()), zap.Error(releaseErr))
 		}
 
 		return err
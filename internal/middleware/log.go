@@ -10,10 +10,12 @@ import (
 
 // LogMiddleware 日志中间件
 //
+//	后续中间件或处理器返回的错误在此处交给 ErrorHandler 生成响应，以记录最终的状态码
+//
 //	param logger *zap.Logger
 //	return fiber.Handler
 //	author centonhuang
-//	update 2025-01-05 21:21:46
+//	update 2026-10-18 16:46:02
 func LogMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		start := time.Now().UTC()
@@ -21,6 +23,11 @@ func LogMiddleware() fiber.Handler {
 		query := string(c.Request().URI().QueryString())
 
 		err := c.Next()
+		if err != nil {
+			if handleErr := c.App().ErrorHandler(c, err); handleErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
 
 		logger := logger.WithFCtx(c)
 
@@ -38,13 +45,17 @@ func LogMiddleware() fiber.Handler {
 			zap.String("rsp-content-type", c.GetRespHeader("Content-Type")),
 		}
 
-		if err != nil {
+		switch {
+		case c.Response().StatusCode() >= fiber.StatusInternalServerError:
 			fields = append([]zap.Field{zap.Error(err)}, fields...)
 			logger.Error("[FIBER] error", fields...)
-		} else {
+		case err != nil:
+			fields = append([]zap.Field{zap.Error(err)}, fields...)
+			logger.Warn("[FIBER] warn", fields...)
+		default:
 			logger.Info("[FIBER] info", fields...)
 		}
 
-		return err
+		return nil
 	}
 }
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/hcd233/go-backend-tmpl/internal/metrics"
+)
+
+// unmatchedRoute 未匹配到任何路由的请求使用的 route 标签，避免按实际路径打标签导致基数失控
+const unmatchedRoute = "unmatched"
+
+// MetricsMiddleware 请求指标中间件，按方法、路由模板与状态码记录请求数、耗时以及请求与响应体大小
+//
+//	需放在 TraceMiddleware 之后、其他会处理错误的中间件之前，以便区分路由未匹配产生的 404/405
+//
+//	return fiber.Handler
+//	author centonhuang
+//	update 2026-10-18 17:32:02
+func MetricsMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		// 路由匹配在 Next 中完成，之后才能取到路由模板；
+		// 没有处理函数匹配时最后匹配的是 app.Use 注册的全局中间件，其路径不能代表请求的路由
+		route := unmatchedRoute
+		err := c.Next()
+		if !isRouteNotFound(err) {
+			route = c.Route().Path
+		}
+
+		// 在此处生成错误响应，使指标记录最终的状态码
+		if err != nil {
+			if err = c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		labels := []string{utils.CopyString(c.Method()), utils.CopyString(route), strconv.Itoa(status)}
+		metrics.HTTPRequestsTotal.WithLabelValues(labels...).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+		metrics.HTTPRequestSize.WithLabelValues(labels...).Observe(float64(len(c.Request().Body())))
+		metrics.HTTPResponseSize.WithLabelValues(labels...).Observe(float64(len(c.Response().Body())))
+		return nil
+	}
+}
+
+// isRouteNotFound 错误是否由 Fiber 路由未匹配产生，业务返回的 404 使用 protocol.Error，不在此列
+func isRouteNotFound(err error) bool {
+	var fiberErr *fiber.Error
+	if !errors.As(err, &fiberErr) {
+		return false
+	}
+	return fiberErr.Code == fiber.StatusNotFound || fiberErr.Code == fiber.StatusMethodNotAllowed
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/hcd233/go-backend-tmpl/internal/logger"
 	"github.com/hcd233/go-backend-tmpl/internal/protocol"
 	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
-	"github.com/hcd233/go-backend-tmpl/internal/util"
 	"go.uber.org/zap"
 )
 
@@ -25,10 +24,7 @@ func LimitUserPermissionMiddleware(serviceName string, requiredPermission model.
 				zap.String("serviceName", serviceName),
 				zap.String("requiredPermission", string(requiredPermission)),
 				zap.String("permission", string(permission)))
-			util.SendHTTPResponse(c, nil, protocol.ErrNoPermission)
-			return c.Status(fiber.StatusForbidden).JSON(protocol.HTTPResponse{
-				Error: protocol.ErrNoPermission.Error(),
-			})
+			return protocol.ErrNoPermission
 		}
 
 		return c.Next()
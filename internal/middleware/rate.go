@@ -2,83 +2,123 @@ package middleware
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hcd233/go-backend-tmpl/internal/constant"
 	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/metrics"
 	"github.com/hcd233/go-backend-tmpl/internal/protocol"
-	"github.com/hcd233/go-backend-tmpl/internal/resource/cache"
-	"github.com/hcd233/go-backend-tmpl/internal/util"
-	"github.com/samber/lo"
-	"github.com/ulule/limiter/v3"
-	"github.com/ulule/limiter/v3/drivers/store/redis"
+	"github.com/hcd233/go-backend-tmpl/internal/ratelimit"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
 	"go.uber.org/zap"
 )
 
-// RateLimiterMiddleware 限频中间件
+const (
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// RateLimiterMiddleware 固定窗口限频中间件
 //
 //	param serviceName string
-//	param key string
+//	param key string 限频对象在 Locals 中的键，为空时按 IP 限频
 //	param period time.Duration
 //	param limit int64
 //	return fiber.Handler
 //	author centonhuang
-//	update 2025-01-05 15:06:44
+//	update 2026-10-18 16:22:02
 func RateLimiterMiddleware(serviceName, key string, period time.Duration, limit int64) fiber.Handler {
-	// 创建限频规则
-	rate := limiter.Rate{
-		Period: period,
-		Limit:  limit,
-	}
-
-	redisClient := cache.GetRedisClient()
-	// 使用Redis存储限频数据
-	store := lo.Must1(redis.NewStoreWithOptions(redisClient, limiter.StoreOptions{
-		Prefix: serviceName,
-	}))
-
-	// 创建限频实例
-	instance := limiter.New(store, rate)
+	policy := &ratelimit.Policy{Windows: []ratelimit.Window{{Period: period, Limit: limit}}}
 
 	return func(c *fiber.Ctx) error {
-		var keyValue, value string
+		var limiterKey string
 		if key == "" {
-			keyValue = "ip"
-			value = c.IP() // 如果没有指定的参数，则使用 IP 地址作为 key
+			// 如果没有指定的参数，则使用 IP 地址作为 key
+			limiterKey = fmt.Sprintf("%s:ip:%s", serviceName, c.IP())
 		} else {
-			value = fmt.Sprintf("%v", c.Locals(key))
+			limiterKey = fmt.Sprintf("%s:%s:%v", serviceName, key, c.Locals(key))
 		}
 
-		// 设置限频 key
-		limiterKey := fmt.Sprintf("%s:%v", keyValue, value)
-		c.Locals(constant.CtxKeyLimiter, limiterKey)
+		return applyRateLimit(c, serviceName, limiterKey, policy, 1)
+	}
+}
 
-		// 检查限频
-		context, err := instance.Get(c.Context(), limiterKey)
+// TieredRateLimiterMiddleware 按用户权限分级限频中间件，需放在 JwtMiddleware 之后
+//
+//	同一用户的所有路由共享限频窗口与配额，cost 为本路由每次请求消耗的次数；
+//	管理员为用户单独设置的策略优先于权限对应的默认策略。
+//
+//	param serviceName string
+//	param cost int64
+//	return fiber.Handler
+//	author centonhuang
+//	update 2026-10-18 17:46:26
+func TieredRateLimiterMiddleware(serviceName string, cost int64) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID := c.Locals(constant.CtxKeyUserID).(uint)
+		permission := c.Locals(constant.CtxKeyPermission).(model.Permission)
+
+		policy, _, err := ratelimit.PolicyFor(c.UserContext(), userID, permission)
 		if err != nil {
-			logger.WithFCtx(c).Error("[RateLimiterMiddleware] failed to get rate limit", zap.Error(err))
-			return c.Status(fiber.StatusInternalServerError).JSON(protocol.HTTPResponse{
-				Error: protocol.ErrInternalError.Error(),
-			})
+			logger.WithFCtx(c).Error("[RateLimiterMiddleware] failed to get rate limit policy", zap.Uint("userID", userID), zap.Error(err))
+			return protocol.ErrInternalError.WithCause(err)
 		}
 
-		if context.Reached {
-			fields := []zap.Field{zap.String("serviceName", serviceName)}
+		return applyRateLimit(c, serviceName, fmt.Sprintf("user:%d", userID), policy, cost)
+	}
+}
 
-			if key == "" {
-				fields = append(fields, zap.String("key", "ip"), zap.String("value", c.IP()))
-			} else {
-				fields = append(fields, zap.String("key", key), zap.String("value", value))
-			}
+// applyRateLimit 消耗限频次数并设置 X-RateLimit-* 响应头，超出时设置 Retry-After 并拒绝请求
+//
+//	param c *fiber.Ctx
+//	param serviceName string
+//	param limiterKey string
+//	param policy *ratelimit.Policy
+//	param cost int64
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:46:32
+func applyRateLimit(c *fiber.Ctx, serviceName, limiterKey string, policy *ratelimit.Policy, cost int64) error {
+	c.Locals(constant.CtxKeyLimiter, limiterKey)
 
-			logger.WithFCtx(c).Error("[RateLimiterMiddleware] rate limit reached", fields...)
-			util.SendHTTPResponse(c, nil, protocol.ErrTooManyRequests)
-			return c.Status(fiber.StatusTooManyRequests).JSON(protocol.HTTPResponse{
-				Error: protocol.ErrTooManyRequests.Error(),
-			})
-		}
+	result, err := ratelimit.Default().Allow(c.UserContext(), limiterKey, policy, cost)
+	if err != nil {
+		logger.WithFCtx(c).Error("[RateLimiterMiddleware] failed to get rate limit", zap.String("serviceName", serviceName), zap.String("limiterKey", limiterKey), zap.Error(err))
+		return protocol.ErrInternalError.WithCause(err)
+	}
+
+	if result.Limit > 0 {
+		c.Set(rateLimitLimitHeader, strconv.FormatInt(result.Limit, 10))
+		c.Set(rateLimitRemainingHeader, strconv.FormatInt(result.Remaining, 10))
+		c.Set(rateLimitResetHeader, strconv.Itoa(ceilSeconds(result.Reset)))
+	}
 
+	if result.Allowed {
 		return c.Next()
 	}
+
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+
+	fields := []zap.Field{
+		zap.String("serviceName", serviceName),
+		zap.String("limiterKey", limiterKey),
+		zap.Int64("cost", cost),
+		zap.Duration("retryAfter", result.RetryAfter),
+	}
+	if result.QuotaExceeded {
+		metrics.RateLimitRejectionsTotal.WithLabelValues(serviceName, "quota").Inc()
+		logger.WithFCtx(c).Info("[RateLimiterMiddleware] quota exceeded", fields...)
+		return protocol.ErrInsufficientQuota
+	}
+	metrics.RateLimitRejectionsTotal.WithLabelValues(serviceName, "rate").Inc()
+	logger.WithFCtx(c).Info("[RateLimiterMiddleware] rate limit reached", fields...)
+	return protocol.ErrTooManyRequests
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
 }
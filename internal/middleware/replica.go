@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/constant"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/cache"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+	"go.uber.org/zap"
+)
+
+const readYourWritesNamespaceName = "readYourWrites"
+
+// ReadYourWritesMiddleware 写后读一致性中间件，需注册在 JwtMiddleware 之后
+//
+//	写请求本身走主库，成功后记录该用户最近一次写入；DatabaseReplicaStickyWindow 内该用户的读请求同样走主库，
+//	避免副本复制延迟导致读不到刚写入的数据。未配置只读副本或窗口为0时直接放行。
+//
+//	return fiber.Handler
+//	author centonhuang
+//	update 2026-10-18 19:44:08
+func ReadYourWritesMiddleware() fiber.Handler {
+	ns := cache.NewNamespace(readYourWritesNamespaceName, config.DatabaseReplicaStickyWindow)
+
+	return func(c *fiber.Ctx) error {
+		userID, ok := c.Locals(constant.CtxKeyUserID).(uint)
+		if !ok || !ns.Enabled() || len(config.DatabaseReplicas) == 0 {
+			return c.Next()
+		}
+
+		ctx := c.UserContext()
+		key := fmt.Sprintf("%d", userID)
+		method := c.Method()
+		isWrite := method != fiber.MethodGet && method != fiber.MethodHead && method != fiber.MethodOptions
+
+		sticky := isWrite
+		if !sticky {
+			_, hit, err := ns.Get(ctx, key)
+			if err != nil {
+				// 无法确认是否在窗口内时走主库，宁可多读主库也不返回过期数据
+				logger.WithFCtx(c).Error("[ReadYourWritesMiddleware] failed to get last write", zap.Uint("userID", userID), zap.Error(err))
+			}
+			sticky = hit || err != nil
+		}
+		if sticky {
+			c.SetUserContext(database.WithPrimary(ctx))
+		}
+
+		err := c.Next()
+		if !isWrite || err != nil || c.Response().StatusCode() >= fiber.StatusBadRequest {
+			return err
+		}
+
+		if setErr := ns.Set(ctx, key, []byte{1}, 0); setErr != nil {
+			// 响应已经生成，记录失败只影响后续读请求的路由
+			logger.WithFCtx(c).Error("[ReadYourWritesMiddleware] failed to record last write", zap.Uint("userID", userID), zap.Error(setErr))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/constant"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+)
+
+// newReadYourWritesApp 以 X-User-Id 请求头模拟已鉴权用户，读请求返回是否走主库
+func newReadYourWritesApp(t *testing.T) *fiber.App {
+	t.Helper()
+
+	previous := config.DatabaseReplicas
+	config.DatabaseReplicas = []string{"127.0.0.1:5433"}
+	t.Cleanup(func() { config.DatabaseReplicas = previous })
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		if userID := c.Get("X-User-Id"); userID != "" {
+			c.Locals(constant.CtxKeyUserID, uint(len(userID)))
+		}
+		return c.Next()
+	}, ReadYourWritesMiddleware())
+
+	app.Get("/user", func(c *fiber.Ctx) error {
+		if database.IsPrimaryForced(c.UserContext()) {
+			return c.SendString("primary")
+		}
+		return c.SendString("replica")
+	})
+	app.Patch("/user", func(c *fiber.Ctx) error {
+		c.Set("X-Primary", strconv.FormatBool(database.IsPrimaryForced(c.UserContext())))
+		return c.SendStatus(c.QueryInt("status", fiber.StatusOK))
+	})
+	return app
+}
+
+func readRoute(t *testing.T, app *fiber.App, userID string) string {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodGet, "/user", nil)
+	req.Header.Set("X-User-Id", userID)
+	rsp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	body := make([]byte, 16)
+	n, _ := rsp.Body.Read(body)
+	return string(body[:n])
+}
+
+// write 发送写请求，返回写请求本身是否走主库
+func write(t *testing.T, app *fiber.App, userID, status string) bool {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/user?status="+status, nil)
+	req.Header.Set("X-User-Id", userID)
+	rsp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return rsp.Header.Get("X-Primary") == "true"
+}
+
+func TestReadYourWritesMiddleware(t *testing.T) {
+	app := newReadYourWritesApp(t)
+
+	if route := readRoute(t, app, "a"); route != "replica" {
+		t.Fatalf("read before write: want replica, got %s", route)
+	}
+
+	if !write(t, app, "a", "400") {
+		t.Fatal("write request should use the primary")
+	}
+	if route := readRoute(t, app, "a"); route != "replica" {
+		t.Fatalf("read after failed write: want replica, got %s", route)
+	}
+
+	write(t, app, "a", "200")
+	if route := readRoute(t, app, "a"); route != "primary" {
+		t.Fatalf("read after write: want primary, got %s", route)
+	}
+	if route := readRoute(t, app, "bb"); route != "replica" {
+		t.Fatalf("read of another user: want replica, got %s", route)
+	}
+	if route := readRoute(t, app, ""); route != "replica" {
+		t.Fatalf("unauthenticated read: want replica, got %s", route)
+	}
+}
+
+func TestReadYourWritesMiddlewareWithoutReplicas(t *testing.T) {
+	app := newReadYourWritesApp(t)
+	config.DatabaseReplicas = nil
+
+	write(t, app, "ccc", "200")
+	if route := readRoute(t, app, "ccc"); route != "replica" {
+		t.Fatalf("without replicas reads should not be forced to the primary, got %s", route)
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/protocol"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+	"go.uber.org/zap"
+)
+
+// bindTenant 将已鉴权用户所属的租户写入上下文供DAO做租户隔离，由 JwtMiddleware 鉴权后调用，新增路由组时不会遗漏
+//
+//	租户只取自鉴权主体，X-Tenant-Id 请求头仅用于校验：与用户所属租户不一致时拒绝请求
+//
+//	param c *fiber.Ctx
+//	param tenantID uint 用户所属租户，0表示未分配租户
+//	return error
+//	author centonhuang
+//	update 2026-10-18 20:16:02
+func bindTenant(c *fiber.Ctx, tenantID uint) error {
+	if tenantID != 0 {
+		c.SetUserContext(database.WithTenant(c.UserContext(), tenantID))
+	}
+
+	tenantHeader := c.Get("X-Tenant-Id")
+	if tenantHeader == "" {
+		return nil
+	}
+
+	requested, err := strconv.ParseUint(tenantHeader, 10, 0)
+	if err != nil || requested == 0 {
+		logger.WithFCtx(c).Info("[JwtMiddleware] invalid tenant id", zap.String("tenantID", tenantHeader), zap.Error(err))
+		return protocol.ErrBadRequest.WithMessageKey("error.tenant.invalidHeader", nil)
+	}
+	if tenantID == 0 || uint(requested) != tenantID {
+		logger.WithFCtx(c).Info("[JwtMiddleware] tenant id does not match the user's tenant",
+			zap.Uint64("requested", requested), zap.Uint("tenantID", tenantID))
+		return protocol.ErrNoPermission.WithMessageKey("error.tenant.mismatch", nil)
+	}
+	return nil
+}
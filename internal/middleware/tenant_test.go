@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hcd233/go-backend-tmpl/internal/protocol"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+)
+
+// newTenantApp 以 X-User-Tenant 请求头模拟已鉴权用户所属的租户，返回上下文中的租户或错误码
+func newTenantApp() *fiber.App {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		userTenant, _ := strconv.ParseUint(c.Get("X-User-Tenant"), 10, 0)
+		if err := bindTenant(c, uint(userTenant)); err != nil {
+			var protocolErr *protocol.Error
+			if errors.As(err, &protocolErr) {
+				return c.SendString(protocolErr.Code)
+			}
+			return err
+		}
+
+		tenantID, ok := database.TenantFromContext(c.UserContext())
+		if !ok {
+			return c.SendString("none")
+		}
+		return c.SendString(strconv.FormatUint(uint64(tenantID), 10))
+	})
+	return app
+}
+
+func TestBindTenant(t *testing.T) {
+	app := newTenantApp()
+
+	cases := []struct {
+		name, userTenant, header, want string
+	}{
+		{"no tenant", "0", "", "none"},
+		{"user tenant", "7", "", "7"},
+		{"matching header", "7", "7", "7"},
+		{"mismatched header", "7", "8", protocol.ErrNoPermission.Code},
+		{"header without user tenant", "0", "7", protocol.ErrNoPermission.Code},
+		{"invalid header", "7", "abc", protocol.ErrBadRequest.Code},
+		{"zero header", "7", "0", protocol.ErrBadRequest.Code},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		req.Header.Set("X-User-Tenant", tc.userTenant)
+		if tc.header != "" {
+			req.Header.Set("X-Tenant-Id", tc.header)
+		}
+		rsp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		body, _ := io.ReadAll(rsp.Body)
+		if got := string(body); got != tc.want {
+			t.Errorf("%s: got %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
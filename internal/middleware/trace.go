@@ -1,28 +1,84 @@
 package middleware
 
 import (
+	"context"
+	"net/http"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 	"github.com/google/uuid"
 	"github.com/hcd233/go-backend-tmpl/internal/constant"
+	"github.com/hcd233/go-backend-tmpl/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// TraceMiddleware 追踪中间件
+const traceIDHeader = "X-Trace-Id"
+
+// TraceMiddleware 追踪中间件，按 W3C traceparent 续接上游链路或开启新链路
+//
+//	请求 Span 保存在 Locals 中供下游的 tracing.Start 取用，链路ID写入 Locals 与 X-Trace-Id 响应头，
+//	日志中的 traceID 与上报的链路一致。Span 名使用路由模板，如 GET /v1/user/:userID。
+//	同时设置 c.UserContext() 作为下游服务使用的请求上下文。
 //
 //	return fiber.Handler
 //	author centonhuang
-//	update 2025-01-05 15:30:00
+//	update 2026-10-18 17:47:02
 func TraceMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		traceID := c.Get("X-Trace-Id")
+		headers := http.Header{}
+		c.Request().Header.VisitAll(func(key, value []byte) {
+			headers.Add(string(key), string(value))
+		})
+		// 服务关闭时 fasthttp 会立即取消所有请求的 c.Context()，处理中的请求改用不随关闭取消的上下文，
+		// 其中的值仍来自 Fiber Locals
+		ctx := context.WithoutCancel(c.Context())
+		ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(headers))
+
+		// Fiber 返回的字符串引用请求缓冲区，请求结束后会被复用，Span 异步上报前必须复制
+		method := utils.CopyString(c.Method())
+		ctx, span := tracing.Tracer().Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(method),
+				semconv.URLPath(utils.CopyString(c.Path())),
+				semconv.URLScheme(utils.CopyString(c.Protocol())),
+				semconv.ClientAddress(utils.CopyString(c.IP())),
+				semconv.UserAgentOriginal(utils.CopyString(c.Get(fiber.HeaderUserAgent))),
+			),
+		)
+		defer span.End()
 
-		if traceID == "" {
+		traceID := span.SpanContext().TraceID().String()
+		if !span.SpanContext().IsValid() {
+			// 未初始化 TracerProvider 时 Span 为空实现，回退为随机ID
 			traceID = uuid.New().String()
 		}
-
+		c.SetUserContext(ctx)
+		c.Locals(constant.CtxKeySpan, span)
 		c.Locals(constant.CtxKeyTraceID, traceID)
+		c.Set(traceIDHeader, traceID)
+
+		// 在此处生成错误响应，使 Span 记录最终的状态码
+		if err := c.Next(); err != nil {
+			if err = c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
 
-		c.Set("X-Trace-Id", traceID)
+		// 路由匹配在 Next 中完成，之后才能取到路由模板
+		route := utils.CopyString(c.Route().Path)
+		span.SetName(method + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route))
 
-		return c.Next()
+		status := c.Response().StatusCode()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		return nil
 	}
 }
@@ -1,13 +1,63 @@
 package middleware
 
 import (
+	"net/url"
+	"reflect"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/hcd233/go-backend-tmpl/internal/i18n"
 	"github.com/hcd233/go-backend-tmpl/internal/logger"
 	"github.com/hcd233/go-backend-tmpl/internal/protocol"
-	"github.com/hcd233/go-backend-tmpl/internal/util"
+	"github.com/hcd233/go-backend-tmpl/internal/validation"
 	"go.uber.org/zap"
 )
 
+// RawQueryParser 需要自行解析原始查询参数的参数类型，如 filter[field][op]=value
+//
+//	author centonhuang
+//	update 2026-10-18 14:46:02
+type RawQueryParser interface {
+	ParseRawQuery(values url.Values) error
+}
+
+// newBindTarget 按原型的类型为每个请求分配新的绑定目标，避免并发请求共享同一实例
+//
+//	param prototype interface{}
+//	return interface{}
+//	author centonhuang
+//	update 2026-10-18 14:46:08
+func newBindTarget(prototype interface{}) interface{} {
+	typ := reflect.TypeOf(prototype)
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		return prototype
+	}
+	return reflect.New(typ.Elem()).Interface()
+}
+
+// validateBindTarget 按 binding 标签校验绑定结果
+//
+//	param c *fiber.Ctx
+//	param middlewareName string
+//	param target interface{}
+//	return error 校验失败时为附带逐字段错误详情的 protocol.ErrBadRequest
+//	author centonhuang
+//	update 2026-10-18 16:44:02
+func validateBindTarget(c *fiber.Ctx, middlewareName string, target interface{}) error {
+	err := validation.Struct(target)
+	if err == nil {
+		return nil
+	}
+
+	details := validation.Details(err, i18n.Negotiate(c))
+	if details == nil {
+		logger.WithFCtx(c).Error("["+middlewareName+"] failed to validate", zap.Error(err))
+		return protocol.ErrInternalError.WithCause(err)
+	}
+
+	logger.WithFCtx(c).Info("["+middlewareName+"] validation failed", zap.Error(err))
+	return protocol.ErrBadRequest.WithDetails(details...)
+}
+
 // ValidateURIMiddleware 验证URI中间件
 //
 //	param uri interface{}
@@ -16,12 +66,13 @@ import (
 //	update 2024-09-21 07:47:53
 func ValidateURIMiddleware(uri interface{}) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		uri := newBindTarget(uri)
 		if err := c.ParamsParser(uri); err != nil {
 			logger.WithFCtx(c).Info("[ValidateURIMiddleware] failed to bind uri", zap.Error(err))
-			util.SendHTTPResponse(c, nil, protocol.ErrBadRequest)
-			return c.Status(fiber.StatusBadRequest).JSON(protocol.HTTPResponse{
-				Error: protocol.ErrBadRequest.Error(),
-			})
+			return protocol.ErrBadRequest.WithCause(err)
+		}
+		if err := validateBindTarget(c, "ValidateURIMiddleware", uri); err != nil {
+			return err
 		}
 		c.Locals("uri", uri)
 		return c.Next()
@@ -36,12 +87,23 @@ func ValidateURIMiddleware(uri interface{}) fiber.Handler {
 //	update 2024-09-21 07:48:40
 func ValidateParamMiddleware(param interface{}) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		param := newBindTarget(param)
 		if err := c.QueryParser(param); err != nil {
 			logger.WithFCtx(c).Info("[ValidateParamMiddleware] failed to bind param", zap.Error(err))
-			util.SendHTTPResponse(c, nil, protocol.ErrBadRequest)
-			return c.Status(fiber.StatusBadRequest).JSON(protocol.HTTPResponse{
-				Error: protocol.ErrBadRequest.Error(),
-			})
+			return protocol.ErrBadRequest.WithCause(err)
+		}
+		if parser, ok := param.(RawQueryParser); ok {
+			values, err := url.ParseQuery(string(c.Request().URI().QueryString()))
+			if err == nil {
+				err = parser.ParseRawQuery(values)
+			}
+			if err != nil {
+				logger.WithFCtx(c).Info("[ValidateParamMiddleware] failed to parse raw query", zap.Error(err))
+				return protocol.ErrBadRequest.WithCause(err)
+			}
+		}
+		if err := validateBindTarget(c, "ValidateParamMiddleware", param); err != nil {
+			return err
 		}
 		c.Locals("param", param)
 		return c.Next()
@@ -56,12 +118,13 @@ func ValidateParamMiddleware(param interface{}) fiber.Handler {
 //	update 2024-09-21 08:48:25
 func ValidateBodyMiddleware(body interface{}) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		body := newBindTarget(body)
 		if err := c.BodyParser(body); err != nil {
 			logger.WithFCtx(c).Info("[ValidateBodyMiddleware] failed to bind body", zap.Error(err))
-			util.SendHTTPResponse(c, nil, protocol.ErrBadRequest)
-			return c.Status(fiber.StatusBadRequest).JSON(protocol.HTTPResponse{
-				Error: protocol.ErrBadRequest.Error(),
-			})
+			return protocol.ErrBadRequest.WithCause(err)
+		}
+		if err := validateBindTarget(c, "ValidateBodyMiddleware", body); err != nil {
+			return err
 		}
 		c.Locals("body", body)
 		return c.Next()
@@ -11,7 +11,17 @@ type RefreshTokenBody struct {
 // UpdateUserBody 更新用户请求体
 //
 //	author centonhuang
-//	update 2024-09-18 02:39:31
+//	update 2026-10-18 16:56:14
 type UpdateUserBody struct {
-	UserName string `json:"userName" binding:"required"`
+	UserName string `json:"userName" binding:"required,username"`
+	Locale   string `json:"locale" binding:"omitempty,locale"`
+}
+
+// SetRateLimitOverrideBody 设置用户限频策略请求体
+//
+//	author centonhuang
+//	update 2026-10-18 16:24:02
+type SetRateLimitOverrideBody struct {
+	Windows []*RateLimitWindow `json:"windows" binding:"dive,required"`
+	Quotas  []*RateLimitWindow `json:"quotas" binding:"dive,required"`
 }
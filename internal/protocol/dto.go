@@ -10,6 +10,18 @@ type PageInfo struct {
 	Total    int64 `json:"total"`
 }
 
+// CursorPageInfo 游标分页信息，列表响应统一使用 items + pageInfo 结构
+//
+//	author centonhuang
+//	update 2026-10-18 14:45:20
+type CursorPageInfo struct {
+	Limit      int    `json:"limit"`
+	HasNext    bool   `json:"hasNext"`
+	HasPrev    bool   `json:"hasPrev"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty"`
+}
+
 // PingResponse 健康检查响应
 //
 //	author centonhuang
@@ -18,6 +30,35 @@ type PingResponse struct {
 	Status string `json:"status"`
 }
 
+// LivenessResponse 存活检查响应
+//
+//	author centonhuang
+//	update 2026-10-18 17:52:02
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+// HealthComponent 依赖检查结果
+//
+//	author centonhuang
+//	update 2026-10-18 17:52:08
+type HealthComponent struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status" enums:"up,down"`
+	Critical  bool    `json:"critical"`
+	LatencyMs float64 `json:"latencyMs"`
+}
+
+// ReadinessResponse 就绪检查响应
+//
+//	author centonhuang
+//	update 2026-10-18 17:52:14
+type ReadinessResponse struct {
+	Status     string             `json:"status" enums:"ok,degraded,unavailable,shuttingDown"`
+	Components []*HealthComponent `json:"components"`
+	CheckedAt  string             `json:"checkedAt"`
+}
+
 // RefreshTokenRequest 刷新令牌请求
 //
 //	author centonhuang
@@ -51,10 +92,11 @@ type User struct {
 // CurUser 当前用户
 //
 //	author centonhuang
-//	update 2025-01-05 11:37:32
+//	update 2026-10-18 16:56:20
 type CurUser struct {
 	User
 	Permission string `json:"permission"`
+	Locale     string `json:"locale"`
 }
 
 // GetCurUserInfoRequest 获取当前用户信息请求
@@ -70,7 +112,8 @@ type GetCurUserInfoRequest struct {
 //	author centonhuang
 //	update 2025-01-04 21:00:59
 type GetCurUserInfoResponse struct {
-	User *CurUser `json:"user"`
+	User    *CurUser `json:"user"`
+	Version uint     `json:"-"`
 }
 
 // GetUserInfoRequest 获取用户信息请求
@@ -92,17 +135,107 @@ type GetUserInfoResponse struct {
 // UpdateUserInfoRequest 更新用户信息请求
 //
 //	author centonhuang
-//	update 2025-01-04 21:19:47
+//	update 2026-10-18 16:56:20
 type UpdateUserInfoRequest struct {
 	UserID          uint   `json:"userID"`
 	UpdatedUserName string `json:"updatedUserName"`
+	UpdatedLocale   string `json:"updatedLocale"`
+	ExpectedVersion uint   `json:"expectedVersion"`
 }
 
 // UpdateUserInfoResponse 更新用户信息响应
 //
 //	author centonhuang
 //	update 2025-01-05 11:35:18
-type UpdateUserInfoResponse struct{}
+type UpdateUserInfoResponse struct {
+	Version uint `json:"-"`
+}
+
+// ListUsersRequest 用户列表请求
+//
+//	author centonhuang
+//	update 2026-10-18 14:45:26
+type ListUsersRequest struct {
+	Param *ListParam
+}
+
+// ListUsersResponse 用户列表响应
+//
+//	author centonhuang
+//	update 2026-10-18 14:45:32
+type ListUsersResponse struct {
+	Items    []*CurUser      `json:"items"`
+	PageInfo *CursorPageInfo `json:"pageInfo"`
+}
+
+// RateLimitWindow 限频窗口
+//
+//	author centonhuang
+//	update 2026-10-18 16:24:08
+type RateLimitWindow struct {
+	Period string `json:"period" binding:"required,duration"`
+	Limit  int64  `json:"limit" binding:"required,gt=0"`
+}
+
+// RateLimitPolicy 用户生效的限频策略
+//
+//	author centonhuang
+//	update 2026-10-18 16:24:14
+type RateLimitPolicy struct {
+	Windows    []*RateLimitWindow `json:"windows"`
+	Quotas     []*RateLimitWindow `json:"quotas"`
+	Overridden bool               `json:"overridden"`
+}
+
+// GetRateLimitPolicyRequest 获取用户限频策略请求
+//
+//	author centonhuang
+//	update 2026-10-18 16:24:20
+type GetRateLimitPolicyRequest struct {
+	UserID uint `json:"userID"`
+}
+
+// GetRateLimitPolicyResponse 获取用户限频策略响应
+//
+//	author centonhuang
+//	update 2026-10-18 16:24:26
+type GetRateLimitPolicyResponse struct {
+	Policy *RateLimitPolicy `json:"policy"`
+}
+
+// SetRateLimitOverrideRequest 设置用户限频策略请求
+//
+//	author centonhuang
+//	update 2026-10-18 16:24:32
+type SetRateLimitOverrideRequest struct {
+	UserID  uint               `json:"userID"`
+	Windows []*RateLimitWindow `json:"windows"`
+	Quotas  []*RateLimitWindow `json:"quotas"`
+}
+
+// SetRateLimitOverrideResponse 设置用户限频策略响应
+//
+//	author centonhuang
+//	update 2026-10-18 16:24:38
+type SetRateLimitOverrideResponse struct {
+	Policy *RateLimitPolicy `json:"policy"`
+}
+
+// DeleteRateLimitOverrideRequest 删除用户限频策略请求
+//
+//	author centonhuang
+//	update 2026-10-18 16:24:44
+type DeleteRateLimitOverrideRequest struct {
+	UserID uint `json:"userID"`
+}
+
+// DeleteRateLimitOverrideResponse 删除用户限频策略响应
+//
+//	author centonhuang
+//	update 2026-10-18 16:24:50
+type DeleteRateLimitOverrideResponse struct {
+	Policy *RateLimitPolicy `json:"policy"`
+}
 
 // LoginRequest OAuth2登录请求
 //
@@ -3,52 +3,230 @@
 //	update 2024-09-18 02:33:08
 package protocol
 
-import "errors"
+import (
+	"net/http"
+	"strings"
+)
+
+// Error API错误
+//
+//	Code 为稳定的机器可读错误码，客户端据此判断错误类型；Message 为面向用户的说明。
+//	MessageKey 非空时按请求语言从消息目录翻译说明，Message 作为目录缺失时的回退。
+//	预定义的错误是共享的，通过 WithMessage、WithMessageKey、WithDetails、WithCause 派生副本后再附加信息，
+//	派生的错误与原错误 errors.Is 相等。
+//
+//	author centonhuang
+//	update 2026-10-18 16:53:02
+type Error struct {
+	Code        string
+	Status      int
+	Message     string
+	MessageKey  string
+	MessageArgs map[string]interface{}
+	Details     []*FieldError
+	cause       error
+}
+
+// NewError 创建API错误，说明的消息键为 error.<code>
+//
+//	param code string
+//	param status int HTTP状态码
+//	param message string 消息目录缺失时的说明
+//	return *Error
+//	author centonhuang
+//	update 2026-10-18 16:53:08
+func NewError(code string, status int, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message, MessageKey: messageKeyPrefix + code}
+}
+
+// FromStatus 由HTTP状态码创建API错误，已预定义该状态码的错误时返回预定义的错误
+//
+//	param status int
+//	param message string 消息目录缺失时的说明，为空时使用预定义错误的说明或状态码的标准说明
+//	return *Error
+//	author centonhuang
+//	update 2026-10-18 16:53:14
+func FromStatus(status int, message string) *Error {
+	for _, err := range statusErrors {
+		if err.Status == status {
+			if message == "" {
+				return err
+			}
+			// 保留消息键，使框架生成的错误同样按请求语言翻译
+			derived := *err
+			derived.Message = message
+			return &derived
+		}
+	}
+
+	text := http.StatusText(status)
+	if message == "" {
+		message = text
+	}
+	return NewError(strings.ReplaceAll(text, " ", ""), status, message)
+}
+
+// Error 实现 error 接口
+//
+//	receiver e *Error
+//	return string
+//	author centonhuang
+//	update 2026-10-18 16:40:20
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Code + ": " + e.cause.Error()
+	}
+	return e.Code
+}
+
+// Unwrap 返回被包装的底层错误
+//
+//	receiver e *Error
+//	return error
+//	author centonhuang
+//	update 2026-10-18 16:40:26
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is 错误码相同即视为同一错误
+//
+//	receiver e *Error
+//	param target error
+//	return bool
+//	author centonhuang
+//	update 2026-10-18 16:40:32
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithMessage 派生一个使用固定说明的错误，该说明不再翻译
+//
+//	receiver e *Error
+//	param message string
+//	return *Error
+//	author centonhuang
+//	update 2026-10-18 16:53:20
+func (e *Error) WithMessage(message string) *Error {
+	err := *e
+	err.Message = message
+	err.MessageKey = ""
+	err.MessageArgs = nil
+	return &err
+}
+
+// WithMessageKey 派生一个按消息目录翻译说明的错误
+//
+//	receiver e *Error
+//	param key string 消息键
+//	param args map[string]interface{} 消息占位符参数，含 count 时按其选择复数形式
+//	return *Error
+//	author centonhuang
+//	update 2026-10-18 16:53:26
+func (e *Error) WithMessageKey(key string, args map[string]interface{}) *Error {
+	err := *e
+	err.MessageKey = key
+	err.MessageArgs = args
+	return &err
+}
+
+// WithDetails 派生一个附加字段详情的错误
+//
+//	receiver e *Error
+//	param details ...*FieldError
+//	return *Error
+//	author centonhuang
+//	update 2026-10-18 16:40:44
+func (e *Error) WithDetails(details ...*FieldError) *Error {
+	err := *e
+	err.Details = append(append([]*FieldError(nil), e.Details...), details...)
+	return &err
+}
+
+// WithCause 派生一个包装底层错误的错误，底层错误仅用于日志，不会返回给客户端
+//
+//	receiver e *Error
+//	param cause error
+//	return *Error
+//	author centonhuang
+//	update 2026-10-18 16:40:50
+func (e *Error) WithCause(cause error) *Error {
+	err := *e
+	err.cause = cause
+	return &err
+}
+
+// messageKeyPrefix 错误码对应的消息键前缀
+const messageKeyPrefix = "error."
 
 var (
 
 	// ErrInternalError 内部错误
 	//
 	//	update 2025-01-04 17:35:44
-	ErrInternalError = errors.New("InternalError")
+	ErrInternalError = NewError("InternalError", http.StatusInternalServerError, "Internal server error")
 
 	// ErrUnauthorized 未授权错误
 	//
 	//	update 2025-01-04 17:36:00
-	ErrUnauthorized = errors.New("Unauthorized")
+	ErrUnauthorized = NewError("Unauthorized", http.StatusUnauthorized, "Authentication is required")
 
 	// ErrNoPermission 没有权限错误
 	//
 	//	update 2025-01-04 17:36:00
-	ErrNoPermission = errors.New("NoPermission")
+	ErrNoPermission = NewError("NoPermission", http.StatusForbidden, "Permission denied")
 
 	// ErrDataNotExists 数据不存在错误
 	//
-	//	update 2025-01-04 17:36:00
-	ErrDataNotExists = errors.New("DataNotExists")
+	//	update 2026-10-18 16:40:56
+	ErrDataNotExists = NewError("DataNotExists", http.StatusNotFound, "The requested data does not exist")
 
 	// ErrDataExists 数据已存在错误
 	//
-	//	update 2025-01-04 17:36:00
-	ErrDataExists = errors.New("DataExists")
+	//	update 2026-10-18 16:40:56
+	ErrDataExists = NewError("DataExists", http.StatusConflict, "The data already exists")
+
+	// ErrConflict 请求与资源的当前状态冲突，如条件更新失败或相同的请求仍在处理
+	//
+	//	update 2026-10-18 16:40:56
+	ErrConflict = NewError("Conflict", http.StatusConflict, "The request conflicts with the current state of the resource")
+
+	// ErrUnprocessableEntity 请求无法处理，如幂等键被用于不同的请求
+	//
+	//	update 2026-10-18 16:05:02
+	ErrUnprocessableEntity = NewError("UnprocessableEntity", http.StatusUnprocessableEntity, "The request cannot be processed")
 
 	// ErrTooManyRequests 请求过于频繁错误
 	//
 	//	update 2025-01-04 17:36:00
-	ErrTooManyRequests = errors.New("TooManyRequests")
+	ErrTooManyRequests = NewError("TooManyRequests", http.StatusTooManyRequests, "Too many requests")
 
 	// ErrBadRequest 请求错误
 	//
 	//	update 2025-01-04 17:36:00
-	ErrBadRequest = errors.New("BadRequest")
+	ErrBadRequest = NewError("BadRequest", http.StatusBadRequest, "The request is invalid")
 
 	// ErrInsufficientQuota 配额不足错误
 	//
 	//	update 2025-01-05 18:41:32
-	ErrInsufficientQuota = errors.New("InsufficientQuota")
+	ErrInsufficientQuota = NewError("InsufficientQuota", http.StatusForbidden, "Quota exhausted")
 
 	// ErrNoImplement 未实现错误
 	//
 	//	update 2025-01-05 18:41:32
-	ErrNoImplement = errors.New("NoImplement")
+	ErrNoImplement = NewError("NoImplement", http.StatusNotImplemented, "Not implemented")
 )
+
+// statusErrors FromStatus 按状态码查找的预定义错误，同一状态码取第一个；
+// 业务含义的错误（如 ErrDataNotExists）不在此列，避免未匹配的路由等被误报为业务错误
+var statusErrors = []*Error{
+	ErrBadRequest,
+	ErrUnauthorized,
+	ErrNoPermission,
+	ErrConflict,
+	ErrUnprocessableEntity,
+	ErrTooManyRequests,
+	ErrInternalError,
+	ErrNoImplement,
+}
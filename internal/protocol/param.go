@@ -1,5 +1,19 @@
 package protocol
 
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	filterParamPrefix = "filter["
+	defaultFilterOp   = "eq"
+)
+
+var filterParamRegexp = regexp.MustCompile(`^filter\[([a-zA-Z0-9_]+)\](?:\[([a-z]+)\])?$`)
+
 // GithubCallbackParam Github回调请求参数
 //
 //	author centonhuang
@@ -40,3 +54,53 @@ type PaginateParam struct {
 	*PageParam
 	*QueryParam
 }
+
+// FilterParam 单个过滤条件，来自 filter[field][op]=value
+//
+//	author centonhuang
+//	update 2026-10-18 14:45:02
+type FilterParam struct {
+	Field string
+	Op    string
+	Value string
+}
+
+// ListParam 游标分页列表参数，如 ?filter[name][contains]=x&sort=-created_at&limit=20&cursor=xxx
+//
+//	author centonhuang
+//	update 2026-10-18 14:45:08
+type ListParam struct {
+	Cursor  string        `query:"cursor"`
+	Limit   int           `query:"limit" binding:"omitempty,min=1,max=100"`
+	Sort    string        `query:"sort" binding:"omitempty,max=64"`
+	Filters []FilterParam `query:"-"`
+}
+
+// ParseRawQuery 解析 filter[field][op]=value 形式的过滤条件，省略 op 时为 eq
+//
+//	receiver p *ListParam
+//	param values url.Values
+//	return error
+//	author centonhuang
+//	update 2026-10-18 14:45:14
+func (p *ListParam) ParseRawQuery(values url.Values) error {
+	for key, vals := range values {
+		if !strings.HasPrefix(key, filterParamPrefix) {
+			continue
+		}
+
+		matches := filterParamRegexp.FindStringSubmatch(key)
+		if matches == nil {
+			return fmt.Errorf("invalid filter param %q", key)
+		}
+
+		op := matches[2]
+		if op == "" {
+			op = defaultFilterOp
+		}
+		for _, value := range vals {
+			p.Filters = append(p.Filters, FilterParam{Field: matches[1], Op: op, Value: value})
+		}
+	}
+	return nil
+}
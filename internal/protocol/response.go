@@ -3,8 +3,36 @@ package protocol
 // HTTPResponse 标准响应体
 //
 //	author centonhuang
-//	update 2024-09-16 03:41:34
+//	update 2026-10-18 16:41:02
 type HTTPResponse struct {
-	Data  interface{} `json:"data"`
-	Error string      `json:"error,omitempty"`
+	Data    interface{}   `json:"data"`
+	Error   string        `json:"error,omitempty"`
+	Message string        `json:"message,omitempty"`
+	Details []*FieldError `json:"details,omitempty"`
+}
+
+// ProblemDetails RFC 7807 错误响应体，客户端 Accept 为 application/problem+json 时使用
+//
+//	author centonhuang
+//	update 2026-10-18 16:41:08
+type ProblemDetails struct {
+	Type     string        `json:"type"`
+	Title    string        `json:"title"`
+	Status   int           `json:"status"`
+	Detail   string        `json:"detail,omitempty"`
+	Instance string        `json:"instance,omitempty"`
+	Code     string        `json:"code"`
+	TraceID  string        `json:"traceId,omitempty"`
+	Errors   []*FieldError `json:"errors,omitempty"`
+}
+
+// FieldError 请求参数校验失败的字段详情
+//
+//	author centonhuang
+//	update 2026-10-18 16:31:08
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
 }
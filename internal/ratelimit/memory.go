@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const memorySweepInterval = time.Minute
+
+type memoryEntry struct {
+	count    int64
+	expireAt time.Time
+}
+
+type memoryBackend struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	nextSweep time.Time
+}
+
+// NewMemoryLimiter 创建进程内的限频器，仅在单实例部署或开发环境中使用
+//
+//	return *Limiter
+//	author centonhuang
+//	update 2026-10-18 19:52:08
+func NewMemoryLimiter() *Limiter {
+	return &Limiter{backend: &memoryBackend{entries: map[string]memoryEntry{}}}
+}
+
+func (b *memoryBackend) consume(_ context.Context, key string, windows []window, cost int64) (bool, []windowState, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.sweep(now)
+
+	keys := make([]string, len(windows))
+	states := make([]windowState, len(windows))
+	allowed := true
+	for i, w := range windows {
+		keys[i] = key + ":" + windowKey(w)
+		entry, ok := b.entries[keys[i]]
+		if !ok || !now.Before(entry.expireAt) {
+			entry = memoryEntry{expireAt: now.Add(w.Period)}
+		}
+		b.entries[keys[i]] = entry
+
+		states[i] = windowState{count: entry.count, reset: entry.expireAt.Sub(now)}
+		if entry.count+cost > w.Limit {
+			allowed = false
+		}
+	}
+	if !allowed {
+		return false, states, nil
+	}
+
+	for i := range windows {
+		entry := b.entries[keys[i]]
+		entry.count += cost
+		b.entries[keys[i]] = entry
+		states[i].count = entry.count
+	}
+	return true, states, nil
+}
+
+// sweep 定期清理已过期的计数，避免不再访问的限频对象一直占用内存
+func (b *memoryBackend) sweep(now time.Time) {
+	if now.Before(b.nextSweep) {
+		return
+	}
+	for key, entry := range b.entries {
+		if !now.Before(entry.expireAt) {
+			delete(b.entries, key)
+		}
+	}
+	b.nextSweep = now.Add(memorySweepInterval)
+}
@@ -0,0 +1,113 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/bytedance/sonic"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/cache"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const overrideKeyPattern = "ratelimit:override:%d"
+
+// memoryOverrides 内存模式下的用户策略，userID -> *Policy
+var memoryOverrides sync.Map
+
+// GetOverride 获取管理员为用户单独设置的策略
+//
+//	param ctx context.Context
+//	param userID uint
+//	return *Policy 未设置时为 nil
+//	return error
+//	author centonhuang
+//	update 2026-10-18 16:21:02
+func GetOverride(ctx context.Context, userID uint) (*Policy, error) {
+	rdb := cache.GetRedisClient()
+	if rdb == nil {
+		if policy, ok := memoryOverrides.Load(userID); ok {
+			return policy.(*Policy), nil
+		}
+		return nil, nil
+	}
+
+	value, err := rdb.Get(ctx, overrideKey(userID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	policy := &Policy{}
+	if err := sonic.Unmarshal(value, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// SetOverride 为用户单独设置策略，替代其权限对应的默认策略
+//
+//	param ctx context.Context
+//	param userID uint
+//	param policy *Policy
+//	return error
+//	author centonhuang
+//	update 2026-10-18 16:21:08
+func SetOverride(ctx context.Context, userID uint, policy *Policy) error {
+	rdb := cache.GetRedisClient()
+	if rdb == nil {
+		memoryOverrides.Store(userID, policy)
+		return nil
+	}
+
+	value, err := sonic.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	return rdb.Set(ctx, overrideKey(userID), value, 0).Err()
+}
+
+// DeleteOverride 删除用户的单独策略，恢复为权限对应的默认策略
+//
+//	param ctx context.Context
+//	param userID uint
+//	return error
+//	author centonhuang
+//	update 2026-10-18 16:21:14
+func DeleteOverride(ctx context.Context, userID uint) error {
+	rdb := cache.GetRedisClient()
+	if rdb == nil {
+		memoryOverrides.Delete(userID)
+		return nil
+	}
+	return rdb.Del(ctx, overrideKey(userID)).Err()
+}
+
+// PolicyFor 获取用户生效的策略，单独设置的策略优先于权限对应的默认策略
+//
+//	param ctx context.Context
+//	param userID uint
+//	param permission model.Permission
+//	return policy *Policy
+//	return overridden bool 是否为单独设置的策略
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 16:21:20
+func PolicyFor(ctx context.Context, userID uint, permission model.Permission) (policy *Policy, overridden bool, err error) {
+	policy, err = GetOverride(ctx, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if policy != nil {
+		return policy, true, nil
+	}
+	return TierPolicy(permission), false, nil
+}
+
+func overrideKey(userID uint) string {
+	return cache.Key(fmt.Sprintf(overrideKeyPattern, userID))
+}
@@ -0,0 +1,211 @@
+// Package ratelimit 分级限频与配额
+//
+//	一个策略由多个同时生效的限频窗口（如每秒与每天）和长期配额组成，
+//	每次请求按路由的权重消耗所有窗口，任一窗口耗尽即拒绝。
+//	Redis 未配置时退化为进程内计数。
+//
+//	update 2026-10-18 16:20:02
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/cache"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
+)
+
+const storePrefix = "ratelimit"
+
+// Window 限频窗口
+//
+//	author centonhuang
+//	update 2026-10-18 16:20:08
+type Window struct {
+	Period time.Duration `json:"period"`
+	Limit  int64         `json:"limit"`
+}
+
+// Policy 限频策略
+//
+//	author centonhuang
+//	update 2026-10-18 16:20:14
+type Policy struct {
+	// Windows 限频窗口，耗尽时返回 protocol.ErrTooManyRequests
+	Windows []Window `json:"windows"`
+	// Quotas 长期配额，耗尽时返回 protocol.ErrInsufficientQuota
+	Quotas []Window `json:"quotas"`
+}
+
+// NewPolicy 由 窗口时长 -> 次数 的映射创建策略，窗口按时长升序排列
+//
+//	param windows map[time.Duration]int64
+//	param quotas map[time.Duration]int64
+//	return *Policy
+//	author centonhuang
+//	update 2026-10-18 16:20:20
+func NewPolicy(windows, quotas map[time.Duration]int64) *Policy {
+	return &Policy{Windows: toWindows(windows), Quotas: toWindows(quotas)}
+}
+
+// TierPolicy 权限对应的默认策略，未配置的权限不限频，随配置文件热更新
+//
+//	param permission model.Permission
+//	return *Policy
+//	author centonhuang
+//	update 2026-10-18 18:27:08
+func TierPolicy(permission model.Permission) *Policy {
+	windows, quotas := config.Current().RateLimit.Tier(string(permission))
+	return NewPolicy(windows, quotas)
+}
+
+// Result 限频结果
+//
+//	Limit、Remaining 与 Reset 取自剩余次数最少的窗口，用于设置 X-RateLimit-* 响应头
+//
+//	author centonhuang
+//	update 2026-10-18 16:20:32
+type Result struct {
+	Allowed       bool
+	QuotaExceeded bool
+	Limit         int64
+	Remaining     int64
+	Reset         time.Duration
+	RetryAfter    time.Duration
+}
+
+// backend 限频计数的存储后端
+//
+//	author centonhuang
+//	update 2026-10-18 19:52:14
+type backend interface {
+	// consume 原子地检查并按 cost 消耗 key 的所有窗口，任一窗口剩余不足时不消耗任何窗口；
+	// 放行时返回消耗后的计数，拒绝时返回当前计数
+	consume(ctx context.Context, key string, windows []window, cost int64) (allowed bool, states []windowState, err error)
+}
+
+// Limiter 限频器
+//
+//	author centonhuang
+//	update 2026-10-18 19:52:20
+type Limiter struct {
+	backend backend
+}
+
+var (
+	defaultLimiter     *Limiter
+	defaultLimiterOnce sync.Once
+)
+
+// Default 获取默认的限频器，使用 cache 中的Redis客户端，内存模式下仅在当前进程内计数
+//
+//	需在 cache.InitCache 之后调用
+//
+//	return *Limiter
+//	author centonhuang
+//	update 2026-10-18 19:52:26
+func Default() *Limiter {
+	defaultLimiterOnce.Do(func() {
+		if cache.IsMemoryMode() {
+			defaultLimiter = NewMemoryLimiter()
+			return
+		}
+		defaultLimiter = NewRedisLimiter(cache.GetRedisClient())
+	})
+	return defaultLimiter
+}
+
+// window 带类型的窗口
+type window struct {
+	Window
+	quota bool
+}
+
+// windowState 窗口的计数与距离重置的时间
+type windowState struct {
+	count int64
+	reset time.Duration
+}
+
+// Allow 按权重消耗策略中的所有窗口
+//
+//	检查与消耗在存储后端中原子完成：所有窗口的剩余次数都足够时一起消耗，任一不足时拒绝且不消耗，
+//	并发请求不会使计数超过上限。策略为空时不限频，返回的 Limit 为0。
+//
+//	receiver l *Limiter
+//	param ctx context.Context
+//	param key string 限频对象，如 user:1
+//	param policy *Policy
+//	param cost int64 本次请求的权重
+//	return *Result
+//	return error
+//	author centonhuang
+//	update 2026-10-18 19:52:32
+func (l *Limiter) Allow(ctx context.Context, key string, policy *Policy, cost int64) (*Result, error) {
+	if cost <= 0 {
+		cost = 1
+	}
+
+	windows := make([]window, 0, len(policy.Windows)+len(policy.Quotas))
+	for _, w := range policy.Windows {
+		windows = append(windows, window{Window: w})
+	}
+	for _, w := range policy.Quotas {
+		windows = append(windows, window{Window: w, quota: true})
+	}
+	if len(windows) == 0 {
+		return &Result{Allowed: true}, nil
+	}
+
+	allowed, states, err := l.backend.consume(ctx, key, windows, cost)
+	if err != nil {
+		return nil, err
+	}
+	if allowed {
+		return evaluate(windows, states, 0), nil
+	}
+	return evaluate(windows, states, cost), nil
+}
+
+// evaluate 检查每个窗口能否再消耗 cost 次，并选出剩余次数最少的窗口
+func evaluate(windows []window, states []windowState, cost int64) *Result {
+	result := &Result{Allowed: true, Remaining: -1}
+	for i, w := range windows {
+		remaining := max(w.Limit-states[i].count, 0)
+		reset := max(states[i].reset, 0)
+
+		if remaining < cost {
+			result.Allowed = false
+			result.QuotaExceeded = result.QuotaExceeded || w.quota
+			result.RetryAfter = max(result.RetryAfter, reset)
+		}
+		if result.Remaining < 0 || remaining < result.Remaining {
+			result.Limit = w.Limit
+			result.Remaining = remaining
+			result.Reset = reset
+		}
+	}
+	return result
+}
+
+// windowKey 窗口在限频对象下的键，如 rate:1000
+func windowKey(w window) string {
+	kind := "rate"
+	if w.quota {
+		kind = "quota"
+	}
+	return fmt.Sprintf("%s:%d", kind, w.Period.Milliseconds())
+}
+
+func toWindows(rates map[time.Duration]int64) []Window {
+	windows := make([]Window, 0, len(rates))
+	for period, limit := range rates {
+		windows = append(windows, Window{Period: period, Limit: limit})
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i].Period < windows[j].Period })
+	return windows
+}
@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAllowConsumesAllWindowsOrNone(t *testing.T) {
+	ctx := context.Background()
+	l := NewMemoryLimiter()
+	policy := NewPolicy(map[time.Duration]int64{time.Minute: 5, time.Hour: 100}, map[time.Duration]int64{24 * time.Hour: 3})
+
+	for i := 0; i < 3; i++ {
+		if result, err := l.Allow(ctx, "user:1", policy, 1); err != nil || !result.Allowed {
+			t.Fatalf("request %d: result=%+v err=%v", i, result, err)
+		}
+	}
+
+	result, err := l.Allow(ctx, "user:1", policy, 1)
+	if err != nil {
+		t.Fatalf("allow: %v", err)
+	}
+	if result.Allowed || !result.QuotaExceeded || result.Remaining != 0 || result.Limit != 3 {
+		t.Fatalf("quota exhausted: want rejected by quota, got %+v", result)
+	}
+
+	// 被拒绝的请求不消耗其余窗口
+	for _, w := range []window{{Window: Window{Period: time.Minute, Limit: 5}}, {Window: Window{Period: time.Hour, Limit: 100}}} {
+		if count := l.backend.(*memoryBackend).entries["user:1:"+windowKey(w)].count; count != 3 {
+			t.Fatalf("window %s consumed by rejected requests: count=%d", windowKey(w), count)
+		}
+	}
+}
+
+func TestAllowRejectsCostAboveRemaining(t *testing.T) {
+	ctx := context.Background()
+	l := NewMemoryLimiter()
+	policy := NewPolicy(map[time.Duration]int64{time.Minute: 5}, nil)
+
+	if result, err := l.Allow(ctx, "user:1", policy, 4); err != nil || !result.Allowed || result.Remaining != 1 {
+		t.Fatalf("first request: result=%+v err=%v", result, err)
+	}
+	result, err := l.Allow(ctx, "user:1", policy, 2)
+	if err != nil || result.Allowed || result.QuotaExceeded || result.RetryAfter <= 0 {
+		t.Fatalf("cost above remaining: result=%+v err=%v", result, err)
+	}
+	if result, err := l.Allow(ctx, "user:1", policy, 1); err != nil || !result.Allowed || result.Remaining != 0 {
+		t.Fatalf("cost within remaining: result=%+v err=%v", result, err)
+	}
+}
+
+func TestAllowConcurrentNeverExceedsLimit(t *testing.T) {
+	ctx := context.Background()
+	l := NewMemoryLimiter()
+	policy := NewPolicy(map[time.Duration]int64{time.Second: 10, time.Minute: 20}, nil)
+
+	var allowed atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := l.Allow(ctx, "user:1", policy, 1)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if result.Allowed {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := allowed.Load(); n != 10 {
+		t.Fatalf("want exactly 10 allowed requests, got %d", n)
+	}
+}
+
+func TestAllowWindowResets(t *testing.T) {
+	ctx := context.Background()
+	l := NewMemoryLimiter()
+	policy := NewPolicy(map[time.Duration]int64{50 * time.Millisecond: 1}, nil)
+
+	if result, _ := l.Allow(ctx, "user:1", policy, 1); !result.Allowed {
+		t.Fatal("first request should be allowed")
+	}
+	if result, _ := l.Allow(ctx, "user:1", policy, 1); result.Allowed {
+		t.Fatal("second request in the same window should be rejected")
+	}
+	time.Sleep(60 * time.Millisecond)
+	if result, _ := l.Allow(ctx, "user:1", policy, 1); !result.Allowed {
+		t.Fatal("request after the window resets should be allowed")
+	}
+}
+
+func TestAllowEmptyPolicy(t *testing.T) {
+	result, err := NewMemoryLimiter().Allow(context.Background(), "user:1", NewPolicy(nil, nil), 1)
+	if err != nil || !result.Allowed || result.Limit != 0 {
+		t.Fatalf("empty policy: result=%+v err=%v", result, err)
+	}
+}
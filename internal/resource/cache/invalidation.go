@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/bytedance/sonic"
+	"github.com/google/uuid"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const invalidationChannel = "cache:invalidation"
+
+// instanceID 当前进程的标识，用于忽略自己发布的失效通知
+var instanceID = uuid.NewString()
+
+// invalidation 失效通知
+//
+//	author centonhuang
+//	update 2026-10-18 15:32:02
+type invalidation struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
+}
+
+func publishInvalidation(ctx context.Context, rdb redis.UniversalClient, keys []string) error {
+	message, err := sonic.Marshal(invalidation{Origin: instanceID, Keys: keys})
+	if err != nil {
+		return err
+	}
+	return rdb.Publish(ctx, Key(invalidationChannel), message).Err()
+}
+
+// subscribeInvalidation 订阅失效通知并清除本地缓存，直到 ctx 结束
+//
+//	每次（重新）订阅成功时清空本地缓存，避免断线期间错过的通知导致本地数据过期
+//
+//	param ctx context.Context
+//	param rdb redis.UniversalClient
+//	author centonhuang
+//	update 2026-10-18 15:41:02
+func subscribeInvalidation(ctx context.Context, rdb redis.UniversalClient) {
+	pubsub := rdb.Subscribe(ctx, Key(invalidationChannel))
+	defer pubsub.Close()
+
+	ch := pubsub.ChannelWithSubscriptions()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			switch msg := msg.(type) {
+			case *redis.Subscription:
+				local.purge()
+				logger.Logger().Info("[Cache] subscribed to invalidation channel", zap.String("channel", msg.Channel))
+			case *redis.Message:
+				var payload invalidation
+				if err := sonic.UnmarshalString(msg.Payload, &payload); err != nil {
+					logger.Logger().Warn("[Cache] invalid invalidation message", zap.String("payload", msg.Payload), zap.Error(err))
+					continue
+				}
+				if payload.Origin != instanceID {
+					local.delete(payload.Keys...)
+				}
+			}
+		}
+	}
+}
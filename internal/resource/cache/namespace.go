@@ -0,0 +1,242 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "cache"
+
+var (
+	// local 所有命名空间共享的进程内缓存，总条数受 CacheLocalSize 限制
+	local = newLocalCache(config.CacheLocalSize)
+
+	// namespaces 已创建的命名空间，name -> *Namespace
+	namespaces sync.Map
+)
+
+// Stats 命名空间命中统计
+//
+//	author centonhuang
+//	update 2026-10-18 15:30:20
+type Stats struct {
+	LocalHits  uint64 `json:"localHits"`
+	RemoteHits uint64 `json:"remoteHits"`
+	Misses     uint64 `json:"misses"`
+	Errors     uint64 `json:"errors"`
+}
+
+// Namespace 两级缓存命名空间：进程内LRU在前，Redis在后
+//
+//	删除时通过 Redis 发布订阅通知所有实例清除本地缓存；
+//	未配置 Redis 时仅使用进程内缓存，适用于开发环境单实例运行。
+//
+//	author centonhuang
+//	update 2026-10-18 15:30:26
+type Namespace struct {
+	name     string
+	ttl      time.Duration
+	localTTL time.Duration
+
+	localHits  atomic.Uint64
+	remoteHits atomic.Uint64
+	misses     atomic.Uint64
+	errors     atomic.Uint64
+}
+
+// NewNamespace 获取或创建命名空间，CacheNamespaceTTLs 中的配置优先于 ttl
+//
+//	param name string
+//	param ttl time.Duration 默认缓存时间，不为正时该命名空间不缓存
+//	return *Namespace
+//	author centonhuang
+//	update 2026-10-18 15:30:32
+func NewNamespace(name string, ttl time.Duration) *Namespace {
+	if configured, ok := config.CacheNamespaceTTLs[name]; ok {
+		ttl = configured
+	}
+
+	ns, _ := namespaces.LoadOrStore(name, &Namespace{
+		name:     name,
+		ttl:      ttl,
+		localTTL: min(ttl, config.CacheLocalTTL),
+	})
+	return ns.(*Namespace)
+}
+
+// GetStats 获取所有命名空间的命中统计
+//
+//	return map[string]Stats name -> 统计
+//	author centonhuang
+//	update 2026-10-18 15:30:38
+func GetStats() map[string]Stats {
+	stats := map[string]Stats{}
+	namespaces.Range(func(name, ns any) bool {
+		stats[name.(string)] = ns.(*Namespace).Stats()
+		return true
+	})
+	return stats
+}
+
+// Name 命名空间名
+//
+//	receiver n *Namespace
+//	return string
+//	author centonhuang
+//	update 2026-10-18 15:30:44
+func (n *Namespace) Name() string {
+	return n.name
+}
+
+// Enabled 命名空间是否开启缓存
+//
+//	receiver n *Namespace
+//	return bool
+//	author centonhuang
+//	update 2026-10-18 15:30:50
+func (n *Namespace) Enabled() bool {
+	return n.ttl > 0
+}
+
+// Stats 获取命中统计
+//
+//	receiver n *Namespace
+//	return Stats
+//	author centonhuang
+//	update 2026-10-18 15:30:56
+func (n *Namespace) Stats() Stats {
+	return Stats{
+		LocalHits:  n.localHits.Load(),
+		RemoteHits: n.remoteHits.Load(),
+		Misses:     n.misses.Load(),
+		Errors:     n.errors.Load(),
+	}
+}
+
+// Get 依次查询本地缓存与Redis，Redis命中时回填本地缓存
+//
+//	receiver n *Namespace
+//	param ctx context.Context
+//	param key string
+//	return value []byte
+//	return ok bool 是否命中，命中的值可以为空
+//	return err error Redis 错误，此时 ok 为 false
+//	author centonhuang
+//	update 2026-10-18 15:31:02
+func (n *Namespace) Get(ctx context.Context, key string) (value []byte, ok bool, err error) {
+	if !n.Enabled() {
+		return nil, false, nil
+	}
+
+	fullKey := n.key(key)
+	if value, ok = local.get(fullKey); ok {
+		n.localHits.Add(1)
+		return value, true, nil
+	}
+
+	rdb := GetRedisClient()
+	if rdb == nil {
+		n.misses.Add(1)
+		return nil, false, nil
+	}
+
+	value, err = rdb.Get(ctx, fullKey).Bytes()
+	switch {
+	case err == nil:
+		n.remoteHits.Add(1)
+		local.set(fullKey, value, n.localTTL)
+		return value, true, nil
+	case errors.Is(err, redis.Nil):
+		n.misses.Add(1)
+		return nil, false, nil
+	default:
+		n.errors.Add(1)
+		return nil, false, err
+	}
+}
+
+// Set 同时写入本地缓存与Redis
+//
+//	receiver n *Namespace
+//	param ctx context.Context
+//	param key string
+//	param value []byte
+//	param ttl time.Duration 不为正时使用命名空间的缓存时间
+//	return error
+//	author centonhuang
+//	update 2026-10-18 15:31:08
+func (n *Namespace) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if !n.Enabled() {
+		return nil
+	}
+	if ttl <= 0 {
+		ttl = n.ttl
+	}
+
+	fullKey := n.key(key)
+	rdb := GetRedisClient()
+	if rdb == nil {
+		// 内存模式下本地缓存是唯一的一级，使用完整的缓存时间
+		local.set(fullKey, value, ttl)
+		return nil
+	}
+
+	local.set(fullKey, value, min(ttl, n.localTTL))
+	if err := rdb.Set(ctx, fullKey, value, ttl).Err(); err != nil {
+		n.errors.Add(1)
+		return err
+	}
+	return nil
+}
+
+// Delete 删除本地缓存与Redis中的数据，并通知其他实例清除本地缓存
+//
+//	receiver n *Namespace
+//	param ctx context.Context
+//	param keys ...string
+//	return error
+//	author centonhuang
+//	update 2026-10-18 15:31:14
+func (n *Namespace) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	fullKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		fullKeys = append(fullKeys, n.key(key))
+	}
+	local.delete(fullKeys...)
+
+	rdb := GetRedisClient()
+	if rdb == nil {
+		return nil
+	}
+
+	// 逐个删除，集群模式下多个键可能不在同一个槽
+	if _, err := rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, fullKey := range fullKeys {
+			pipe.Del(ctx, fullKey)
+		}
+		return nil
+	}); err != nil {
+		n.errors.Add(1)
+		return err
+	}
+	if err := publishInvalidation(ctx, rdb, fullKeys); err != nil {
+		n.errors.Add(1)
+		return err
+	}
+	return nil
+}
+
+func (n *Namespace) key(key string) string {
+	return Key(fmt.Sprintf("%s:%s:%s", keyPrefix, n.name, key))
+}
@@ -5,40 +5,224 @@ package cache
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"net"
+	"os"
 
 	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/health"
 	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/tracing"
 	"github.com/redis/go-redis/v9"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
 
-var rdb *redis.Client
+const (
+	// ModeSingle 单节点
+	ModeSingle = "single"
 
-const redisDB = 0
+	// ModeSentinel 哨兵
+	ModeSentinel = "sentinel"
 
-// GetRedisClient 获取Redis客户端
+	// ModeCluster 集群
+	ModeCluster = "cluster"
+)
+
+var (
+	// ErrUnsupportedMode 不支持的Redis部署模式
+	//
+	//	update 2026-10-18 15:40:02
+	ErrUnsupportedMode = errors.New("unsupported redis mode")
+
+	// ErrInvalidRedisConfig Redis配置不合法
+	//
+	//	update 2026-10-18 15:40:08
+	ErrInvalidRedisConfig = errors.New("invalid redis config")
+)
+
+var rdb redis.UniversalClient
+
+// stopSubscriber 停止缓存失效通知的订阅，subscriberDone 在订阅协程退出后关闭
+var (
+	stopSubscriber context.CancelFunc
+	subscriberDone chan struct{}
+)
+
+// GetRedisClient 获取Redis客户端，内存模式下为 nil
 //
-//	return *redis.Client
+//	return redis.UniversalClient
 //	author centonhuang
-//	update 2024-12-09 15:56:40
-func GetRedisClient() *redis.Client {
+//	update 2026-10-18 15:40:14
+func GetRedisClient() redis.UniversalClient {
 	return rdb
 }
 
-// InitCache 初始化Redis客户端
+// Key 为键或频道名加上环境前缀，所有直接访问Redis的代码都应通过它生成键
+//
+//	param key string
+//	return string
+//	author centonhuang
+//	update 2026-10-18 15:40:20
+func Key(key string) string {
+	return config.RedisKeyPrefix + key
+}
+
+// InitCache 按 REDIS_MODE 初始化单节点、哨兵或集群客户端并订阅缓存失效通知，未配置Redis地址时以内存模式运行
 //
 //	author centonhuang
-//	update 2024-12-09 15:56:36
+//	update 2026-10-18 17:51:08
 func InitCache() {
-	rdb = redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("%s:%s", config.RedisHost, config.RedisPort),
-		Password: config.RedisPassword,
-		DB:       redisDB,
-	})
+	addrs := redisAddrs()
+	if len(addrs) == 0 {
+		logger.Logger().Warn("[Cache] Redis is not configured, running in memory mode")
+		return
+	}
+
+	rdb = lo.Must1(newRedisClient(config.RedisMode, addrs))
+	rdb.AddHook(tracing.RedisHook{})
 
 	_ = lo.Must1(rdb.Ping(context.Background()).Result())
+	health.Register("cache", func(ctx context.Context) error {
+		return rdb.Ping(ctx).Err()
+	})
+
+	logger.Logger().Info("[Cache] Connected to Redis database",
+		zap.String("mode", config.RedisMode),
+		zap.Strings("addrs", addrs),
+		zap.Int("db", config.RedisDB),
+		zap.Bool("tls", config.RedisTLS),
+		zap.String("keyPrefix", config.RedisKeyPrefix))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopSubscriber, subscriberDone = cancel, make(chan struct{})
+	go func() {
+		defer close(subscriberDone)
+		subscribeInvalidation(ctx, rdb)
+	}()
+}
+
+// CloseCache 停止缓存失效通知的订阅并关闭Redis连接，内存模式下无需关闭
+//
+//	param ctx context.Context
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:42:08
+func CloseCache(ctx context.Context) error {
+	if rdb == nil {
+		return nil
+	}
+
+	stopSubscriber()
+	select {
+	case <-subscriberDone:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return rdb.Close()
+}
+
+// IsMemoryMode 是否未使用Redis，此时缓存、限频与锁均只在当前进程内生效
+//
+//	return bool
+//	author centonhuang
+//	update 2026-10-18 15:33:08
+func IsMemoryMode() bool {
+	return rdb == nil
+}
+
+// redisAddrs 优先使用 REDIS_ADDRS，未配置时回退为 REDIS_HOST:REDIS_PORT
+func redisAddrs() []string {
+	if len(config.RedisAddrs) > 0 {
+		return config.RedisAddrs
+	}
+	if config.RedisHost == "" {
+		return nil
+	}
+	return []string{net.JoinHostPort(config.RedisHost, config.RedisPort)}
+}
+
+func newRedisClient(mode string, addrs []string) (redis.UniversalClient, error) {
+	tlsConfig, err := newTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	switch mode {
+	case ModeSingle, "":
+		if len(addrs) != 1 {
+			return nil, fmt.Errorf("%w: single mode requires exactly one address, got %d", ErrInvalidRedisConfig, len(addrs))
+		}
+		return redis.NewClient(&redis.Options{
+			Addr:      addrs[0],
+			Username:  config.RedisUsername,
+			Password:  config.RedisPassword,
+			DB:        config.RedisDB,
+			TLSConfig: tlsConfig,
+		}), nil
+	case ModeSentinel:
+		if config.RedisSentinelMaster == "" {
+			return nil, fmt.Errorf("%w: sentinel mode requires REDIS_SENTINEL_MASTER", ErrInvalidRedisConfig)
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       config.RedisSentinelMaster,
+			SentinelAddrs:    addrs,
+			SentinelUsername: config.RedisSentinelUsername,
+			SentinelPassword: config.RedisSentinelPassword,
+			Username:         config.RedisUsername,
+			Password:         config.RedisPassword,
+			DB:               config.RedisDB,
+			TLSConfig:        tlsConfig,
+		}), nil
+	case ModeCluster:
+		if config.RedisDB != 0 {
+			return nil, fmt.Errorf("%w: cluster mode only supports db 0, got %d", ErrInvalidRedisConfig, config.RedisDB)
+		}
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     addrs,
+			Username:  config.RedisUsername,
+			Password:  config.RedisPassword,
+			TLSConfig: tlsConfig,
+		}), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedMode, mode)
+	}
+}
+
+// newTLSConfig 根据 REDIS_TLS_* 配置生成TLS配置，未开启TLS时返回 nil
+func newTLSConfig() (*tls.Config, error) {
+	if !config.RedisTLS {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         config.RedisTLSServerName,
+		InsecureSkipVerify: config.RedisTLSInsecureSkipVerify,
+	}
+
+	if config.RedisTLSCAFile != "" {
+		pem, err := os.ReadFile(config.RedisTLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read redis tls ca file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: no certificate found in %s", ErrInvalidRedisConfig, config.RedisTLSCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.RedisTLSCertFile != "" || config.RedisTLSKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.RedisTLSCertFile, config.RedisTLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load redis tls client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
 
-	logger.Logger().Info("[Cache] Connected to Redis database", zap.String("host", config.RedisHost), zap.String("port", config.RedisPort), zap.Int("db", redisDB))
+	return tlsConfig, nil
 }
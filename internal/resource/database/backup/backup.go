@@ -0,0 +1,376 @@
+// Package backup 数据库逻辑备份与恢复
+//
+//	update 2026-10-18 12:01:05
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+	objdao "github.com/hcd233/go-backend-tmpl/internal/resource/storage/obj_dao"
+	"go.uber.org/zap"
+)
+
+const (
+	fileExt     = ".dump"
+	checksumExt = ".sha256"
+	timeFormat  = "20060102T150405Z"
+
+	pgDumpBin    = "pg_dump"
+	pgRestoreBin = "pg_restore"
+)
+
+var (
+	// ErrDatabaseNotEmpty 恢复目标数据库非空
+	//
+	//	update 2026-10-18 12:01:11
+	ErrDatabaseNotEmpty = errors.New("target database is not empty")
+
+	// ErrChecksumMismatch 备份文件校验和不一致
+	//
+	//	update 2026-10-18 12:01:17
+	ErrChecksumMismatch = errors.New("backup checksum mismatch")
+
+	// ErrNoBackup 对象存储中没有备份
+	//
+	//	update 2026-10-18 12:01:23
+	ErrNoBackup = errors.New("no backup found")
+)
+
+// Result 备份结果
+//
+//	author centonhuang
+//	update 2026-10-18 12:01:29
+type Result struct {
+	Name     string
+	Path     string
+	Size     int64
+	Checksum string
+}
+
+// NewBackupName 生成备份文件名
+//
+//	return string
+//	author centonhuang
+//	update 2026-10-18 12:01:35
+func NewBackupName() string {
+	return fmt.Sprintf("%s-%s%s", config.PostgresDatabase, time.Now().UTC().Format(timeFormat), fileExt)
+}
+
+// Dump 使用 pg_dump 导出一致性快照(schema + data)，custom格式自带压缩，同时写出 .sha256 校验文件
+//
+//	param ctx context.Context
+//	param filePath string
+//	return result *Result
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 12:01:41
+func Dump(ctx context.Context, filePath string) (result *Result, err error) {
+	logger := logger.WithCtx(ctx)
+
+	if err = ensurePostgres(); err != nil {
+		return
+	}
+
+	if err = runPGCommand(ctx, nil, pgDumpBin,
+		"--format=custom", "--compress=6", "--no-owner", "--no-privileges", "--file", filePath); err != nil {
+		return
+	}
+
+	checksum, size, err := fileChecksum(filePath)
+	if err != nil {
+		return
+	}
+	if err = os.WriteFile(filePath+checksumExt, []byte(checksum+"\n"), 0o600); err != nil {
+		return
+	}
+
+	result = &Result{Name: filepath.Base(filePath), Path: filePath, Size: size, Checksum: checksum}
+	logger.Info("[Backup] dump finished", zap.String("path", filePath), zap.Int64("size", size), zap.String("checksum", checksum))
+	return
+}
+
+// Restore 校验备份后恢复到空数据库，并检查备份中的表均已恢复
+//
+//	param ctx context.Context
+//	param filePath string
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 12:01:47
+func Restore(ctx context.Context, filePath string) (err error) {
+	logger := logger.WithCtx(ctx)
+
+	if err = ensurePostgres(); err != nil {
+		return
+	}
+
+	if err = verifyChecksum(filePath); err != nil {
+		return
+	}
+
+	tables, err := listArchiveTables(ctx, filePath)
+	if err != nil {
+		return fmt.Errorf("invalid backup archive: %w", err)
+	}
+
+	if err = ensureEmptyDatabase(ctx); err != nil {
+		return
+	}
+
+	if err = runPGCommand(ctx, nil, pgRestoreBin,
+		"--no-owner", "--no-privileges", "--exit-on-error", "--single-transaction",
+		"--dbname", config.PostgresDatabase, filePath); err != nil {
+		return
+	}
+
+	migrator := database.GetDBInstance(ctx).Migrator()
+	for _, table := range tables {
+		if !migrator.HasTable(table) {
+			return fmt.Errorf("table %s is missing after restore", table)
+		}
+	}
+
+	logger.Info("[Backup] restore finished", zap.String("path", filePath), zap.Strings("tables", tables))
+	return
+}
+
+// ToStorage 备份并上传到对象存储
+//
+//	param ctx context.Context
+//	param dao objdao.BackupObjDAO
+//	return result *Result
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 12:01:53
+func ToStorage(ctx context.Context, dao objdao.BackupObjDAO) (result *Result, err error) {
+	dir, err := os.MkdirTemp("", "db-backup-")
+	if err != nil {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	result, err = Dump(ctx, filepath.Join(dir, NewBackupName()))
+	if err != nil {
+		return
+	}
+
+	if err = dao.UploadFile(ctx, result.Name, result.Path); err != nil {
+		return
+	}
+	if err = dao.UploadFile(ctx, result.Name+checksumExt, result.Path+checksumExt); err != nil {
+		return
+	}
+
+	logger.WithCtx(ctx).Info("[Backup] backup uploaded",
+		zap.String("bucket", dao.GetBucketName(ctx)), zap.String("name", result.Name))
+	return
+}
+
+// FromStorage 从对象存储下载备份并恢复，name 为空时使用最新的备份
+//
+//	param ctx context.Context
+//	param dao objdao.BackupObjDAO
+//	param name string
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 12:01:59
+func FromStorage(ctx context.Context, dao objdao.BackupObjDAO, name string) (err error) {
+	if name == "" {
+		backups, err := List(ctx, dao)
+		if err != nil {
+			return err
+		}
+		if len(backups) == 0 {
+			return ErrNoBackup
+		}
+		name = backups[0].ObjectName
+	}
+
+	dir, err := os.MkdirTemp("", "db-restore-")
+	if err != nil {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, filepath.Base(name))
+	if err = dao.DownloadFile(ctx, name, filePath); err != nil {
+		return
+	}
+	if err = dao.DownloadFile(ctx, name+checksumExt, filePath+checksumExt); err != nil {
+		return fmt.Errorf("download checksum of %s: %w", name, err)
+	}
+
+	return Restore(ctx, filePath)
+}
+
+// List 列出对象存储中的备份，按时间倒序
+//
+//	param ctx context.Context
+//	param dao objdao.BackupObjDAO
+//	return backups []objdao.ObjectInfo
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 12:02:05
+func List(ctx context.Context, dao objdao.BackupObjDAO) (backups []objdao.ObjectInfo, err error) {
+	objectInfos, err := dao.ListObjects(ctx)
+	if err != nil {
+		return
+	}
+	for _, objectInfo := range objectInfos {
+		if strings.HasSuffix(objectInfo.ObjectName, fileExt) {
+			backups = append(backups, objectInfo)
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].LastModified.After(backups[j].LastModified)
+	})
+	return
+}
+
+// ApplyRetention 按保留规则清理旧备份：保留最新的 keep 个，且删除早于 maxAge 的，最新的备份永远保留
+//
+//	param ctx context.Context
+//	param dao objdao.BackupObjDAO
+//	param keep int 小于等于0表示不限制数量
+//	param maxAge time.Duration 小于等于0表示不限制时间
+//	return deleted []string
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 12:02:11
+func ApplyRetention(ctx context.Context, dao objdao.BackupObjDAO, keep int, maxAge time.Duration) (deleted []string, err error) {
+	backups, err := List(ctx, dao)
+	if err != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	for i, backup := range backups {
+		if i == 0 {
+			continue
+		}
+		expiredByCount := keep > 0 && i >= keep
+		expiredByAge := maxAge > 0 && now.Sub(backup.LastModified) > maxAge
+		if !expiredByCount && !expiredByAge {
+			continue
+		}
+
+		if err = dao.DeleteObject(ctx, backup.ObjectName); err != nil {
+			return
+		}
+		if err = dao.DeleteObject(ctx, backup.ObjectName+checksumExt); err != nil {
+			return
+		}
+		deleted = append(deleted, backup.ObjectName)
+	}
+
+	logger.WithCtx(ctx).Info("[Backup] retention applied", zap.Int("total", len(backups)), zap.Strings("deleted", deleted))
+	return
+}
+
+// ensurePostgres 备份恢复依赖 pg_dump / pg_restore，仅支持 postgres 驱动
+func ensurePostgres() error {
+	if config.DatabaseDriver != database.DriverPostgres {
+		return fmt.Errorf("%w: backup only supports %s, got %s", database.ErrUnsupportedDriver, database.DriverPostgres, config.DatabaseDriver)
+	}
+	return nil
+}
+
+func runPGCommand(ctx context.Context, stdout io.Writer, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = append(os.Environ(),
+		"PGHOST="+config.PostgresHost,
+		"PGPORT="+config.PostgresPort,
+		"PGUSER="+config.PostgresUser,
+		"PGPASSWORD="+config.PostgresPassword,
+		"PGDATABASE="+config.PostgresDatabase,
+		"PGSSLMODE="+config.PostgresSSLMode,
+	)
+
+	var stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func listArchiveTables(ctx context.Context, filePath string) (tables []string, err error) {
+	var toc bytes.Buffer
+	if err = runPGCommand(ctx, &toc, pgRestoreBin, "--list", filePath); err != nil {
+		return
+	}
+
+	// TOC 行格式: "215; 1259 16385 TABLE public users owner"
+	scanner := bufio.NewScanner(&toc)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 6 && !strings.HasPrefix(fields[0], ";") && fields[3] == "TABLE" {
+			tables = append(tables, fields[5])
+		}
+	}
+	err = scanner.Err()
+	return
+}
+
+func ensureEmptyDatabase(ctx context.Context) error {
+	var count int64
+	if err := database.GetDBInstance(ctx).Raw(
+		"SELECT count(*) FROM information_schema.tables WHERE table_schema NOT IN ('pg_catalog', 'information_schema')",
+	).Scan(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d tables found", ErrDatabaseNotEmpty, count)
+	}
+	return nil
+}
+
+func fileChecksum(filePath string) (checksum string, size int64, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if size, err = io.Copy(hash, file); err != nil {
+		return
+	}
+	checksum = hex.EncodeToString(hash.Sum(nil))
+	return
+}
+
+func verifyChecksum(filePath string) error {
+	expected, err := os.ReadFile(filePath + checksumExt)
+	if errors.Is(err, os.ErrNotExist) {
+		logger.Logger().Warn("[Backup] checksum file not found, skip verification", zap.String("path", filePath))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	actual, _, err := fileChecksum(filePath)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(string(expected)) != actual {
+		return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, strings.TrimSpace(string(expected)), actual)
+	}
+	return nil
+}
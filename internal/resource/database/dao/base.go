@@ -4,11 +4,74 @@
 package dao
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var (
+	// ErrTenantRequired 租户隔离模型缺少租户上下文
+	//
+	//	update 2026-10-18 10:20:11
+	ErrTenantRequired = errors.New("tenant id is required in context")
+
+	// ErrTenantMismatch 数据的租户与上下文中的租户不一致
+	//
+	//	update 2026-10-18 10:20:15
+	ErrTenantMismatch = errors.New("tenant id mismatch")
+
+	// ErrVersionConflict 条件更新时数据已被修改，可用 errors.Is 判断，具体版本见 VersionConflictError
+	//
+	//	update 2026-10-18 14:22:02
+	ErrVersionConflict = errors.New("version conflict")
+
+	// ErrNotVersioned 模型未嵌入 model.VersionModel
+	//
+	//	update 2026-10-18 14:22:08
+	ErrNotVersioned = errors.New("model is not versioned")
+)
+
+const (
+	tenantColumn  = "tenant_id"
+	versionColumn = "version"
+)
+
+// VersionConflictError 乐观锁冲突错误
+//
+//	author centonhuang
+//	update 2026-10-18 14:22:14
+type VersionConflictError struct {
+	Expected uint
+	Actual   uint
+}
+
+// Error 实现 error 接口
+//
+//	receiver e *VersionConflictError
+//	return string
+//	author centonhuang
+//	update 2026-10-18 14:22:20
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s: expected version %d, actual version %d", ErrVersionConflict, e.Expected, e.Actual)
+}
+
+// Is 使 errors.Is(err, ErrVersionConflict) 成立
+//
+//	receiver e *VersionConflictError
+//	param target error
+//	return bool
+//	author centonhuang
+//	update 2026-10-18 14:22:26
+func (e *VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
+
 // baseDAO 基础DAO
 //
 //	author centonhuang
@@ -53,6 +116,42 @@ type PaginateParam struct {
 	*QueryParam
 }
 
+// tenantScope 为租户隔离模型追加租户条件，未开启租户隔离的模型原样返回
+//
+//	receiver dao *baseDAO[ModelT]
+//	param db *gorm.DB
+//	return *gorm.DB
+//	return error
+//	author centonhuang
+//	update 2026-10-18 10:20:21
+func (dao *baseDAO[ModelT]) tenantScope(db *gorm.DB) (*gorm.DB, error) {
+	if !model.IsTenantScoped(new(ModelT)) {
+		return db, nil
+	}
+
+	ctx := dao.context(db)
+	if database.IsTenantScopeSkipped(ctx) {
+		return db, nil
+	}
+
+	tenantID, ok := database.TenantFromContext(ctx)
+	if !ok {
+		return nil, ErrTenantRequired
+	}
+
+	return db.Where(clause.Eq{
+		Column: clause.Column{Table: clause.CurrentTable, Name: tenantColumn},
+		Value:  tenantID,
+	}).Session(&gorm.Session{}), nil
+}
+
+func (dao *baseDAO[ModelT]) context(db *gorm.DB) context.Context {
+	if db.Statement.Context == nil {
+		return context.Background()
+	}
+	return db.Statement.Context
+}
+
 // Create 创建数据
 //
 //	param dao *BaseDAO[T]
@@ -60,22 +159,109 @@ type PaginateParam struct {
 //	author centonhuang
 //	update 2024-10-17 02:51:49
 func (dao *baseDAO[ModelT]) Create(db *gorm.DB, data *ModelT) (err error) {
+	if tenantModel, ok := any(data).(model.TenantScoped); ok {
+		ctx := dao.context(db)
+		tenantID, ok := database.TenantFromContext(ctx)
+		switch {
+		case ok && tenantModel.GetTenantID() == 0:
+			tenantModel.SetTenantID(tenantID)
+		case ok && tenantModel.GetTenantID() != tenantID:
+			return ErrTenantMismatch
+		case !ok && !database.IsTenantScopeSkipped(ctx):
+			return ErrTenantRequired
+		}
+	}
+
 	err = db.Create(&data).Error
 	return
 }
 
-// Update 使用ID更新数据
+// Update 使用ID更新数据，开启乐观锁的模型同时递增版本号
 //
 //	param dao *BaseDAO[T]
 //	return Update
 //	author centonhuang
-//	update 2024-10-17 02:52:18
+//	update 2026-10-18 14:22:32
 func (dao *baseDAO[ModelT]) Update(db *gorm.DB, data *ModelT, info map[string]interface{}) (err error) {
-	info["updated_at"] = time.Now().UTC()
-	err = db.Model(&data).Updates(info).Error
+	sql, err := dao.tenantScope(db)
+	if err != nil {
+		return
+	}
+	if err = dao.checkTenantColumn(db, data, info); err != nil {
+		return
+	}
+
+	err = sql.Model(&data).Updates(dao.updateColumns(info)).Error
+	return
+}
+
+// UpdateWithVersion 仅当数据库中的版本号等于 version 时更新并递增版本号，成功后 data 的版本号为新版本
+//
+//	数据已被修改时返回 *VersionConflictError，且 data 的版本号为当前版本；数据不存在时返回 gorm.ErrRecordNotFound
+//
+//	param dao *BaseDAO[T]
+//	param db *gorm.DB
+//	param data *ModelT 需要带主键
+//	param version uint 调用方读到的版本号
+//	param info map[string]interface{}
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 14:22:38
+func (dao *baseDAO[ModelT]) UpdateWithVersion(db *gorm.DB, data *ModelT, version uint, info map[string]interface{}) (err error) {
+	versioned, ok := any(data).(model.Versioned)
+	if !ok {
+		return ErrNotVersioned
+	}
+
+	sql, err := dao.tenantScope(db)
+	if err != nil {
+		return
+	}
+	if err = dao.checkTenantColumn(db, data, info); err != nil {
+		return
+	}
+
+	result := sql.Model(&data).Where(clause.Eq{
+		Column: clause.Column{Table: clause.CurrentTable, Name: versionColumn},
+		Value:  version,
+	}).Updates(dao.updateColumns(info))
+	if err = result.Error; err != nil {
+		return
+	}
+
+	if result.RowsAffected == 0 {
+		// 区分数据不存在与版本冲突
+		if err = sql.Select(versionColumn).Take(&data).Error; err != nil {
+			return
+		}
+		return &VersionConflictError{Expected: version, Actual: versioned.GetVersion()}
+	}
+
+	versioned.SetVersion(version + 1)
 	return
 }
 
+// updateColumns 复制调用方的更新字段并补充更新时间与版本号，不修改调用方的 map
+func (dao *baseDAO[ModelT]) updateColumns(info map[string]interface{}) map[string]interface{} {
+	columns := make(map[string]interface{}, len(info)+2)
+	for column, value := range info {
+		columns[column] = value
+	}
+
+	columns["updated_at"] = time.Now().UTC()
+	if model.IsVersioned(new(ModelT)) {
+		columns[versionColumn] = gorm.Expr(versionColumn + " + 1")
+	}
+	return columns
+}
+
+func (dao *baseDAO[ModelT]) checkTenantColumn(db *gorm.DB, data *ModelT, info map[string]interface{}) error {
+	if _, ok := info[tenantColumn]; ok && model.IsTenantScoped(data) && !database.IsTenantScopeSkipped(dao.context(db)) {
+		return ErrTenantMismatch
+	}
+	return nil
+}
+
 // Delete 删除
 //
 //	param dao *BaseDAO[T]
@@ -83,12 +269,20 @@ func (dao *baseDAO[ModelT]) Update(db *gorm.DB, data *ModelT, info map[string]in
 //	author centonhuang
 //	update 2024-10-17 02:52:33
 func (dao *baseDAO[ModelT]) Delete(db *gorm.DB, data *ModelT) (err error) {
-	err = db.Delete(&data).Error
+	sql, err := dao.tenantScope(db)
+	if err != nil {
+		return
+	}
+	err = sql.Delete(&data).Error
 	return
 }
 
 func (dao *baseDAO[ModelT]) BatchDelete(db *gorm.DB, data *[]ModelT) (err error) {
-	err = db.Delete(&data).Error
+	sql, err := dao.tenantScope(db)
+	if err != nil {
+		return
+	}
+	err = sql.Delete(&data).Error
 	return
 }
 
@@ -99,7 +293,11 @@ func (dao *baseDAO[ModelT]) BatchDelete(db *gorm.DB, data *[]ModelT) (err error)
 //	author centonhuang
 //	update 2024-10-17 03:06:57
 func (dao *baseDAO[ModelT]) GetByID(db *gorm.DB, id uint, fields []string, preloads []string) (data *ModelT, err error) {
-	sql := db.Select(fields)
+	sql, err := dao.tenantScope(db)
+	if err != nil {
+		return
+	}
+	sql = sql.Select(fields)
 	for _, preload := range preloads {
 		sql = sql.Preload(preload)
 	}
@@ -115,7 +313,11 @@ func (dao *baseDAO[ModelT]) GetByID(db *gorm.DB, id uint, fields []string, prelo
 //	author centonhuang
 //	update 2024-11-03 07:34:47
 func (dao *baseDAO[ModelT]) BatchGetByIDs(db *gorm.DB, ids []uint, fields []string, preloads []string) (data *[]ModelT, err error) {
-	sql := db.Select(fields)
+	sql, err := dao.tenantScope(db)
+	if err != nil {
+		return
+	}
+	sql = sql.Select(fields)
 	for _, preload := range preloads {
 		sql = sql.Preload(preload)
 	}
@@ -128,20 +330,27 @@ func (dao *baseDAO[ModelT]) BatchGetByIDs(db *gorm.DB, ids []uint, fields []stri
 //	param dao *BaseDAO[T]
 //	return Paginate
 //	author centonhuang
-//	update 2024-10-17 03:09:11
+//	update 2026-10-18 19:31:26
 func (dao *baseDAO[ModelT]) Paginate(db *gorm.DB, fields []string, preloads []string, param *PaginateParam) (data *[]ModelT, pageInfo *PageInfo, err error) {
 	limit, offset := param.PageSize, (param.Page-1)*param.PageSize
 
-	sql := db.Select(fields)
+	scoped, err := dao.tenantScope(db)
+	if err != nil {
+		return
+	}
+
+	sql := scoped.Select(fields)
 	for _, preload := range preloads {
 		sql = sql.Preload(preload)
 	}
 
 	if param.Query != "" && len(param.QueryFields) > 0 {
-		sql = sql.Where("? LIKE ?", param.QueryFields[0], "%"+param.Query+"%")
+		// 查询条件需要成组，避免 OR 绕过租户条件；字段作为列名引用，而不是字符串字面量
+		cond := db.Session(&gorm.Session{NewDB: true}).Where("? LIKE ?", clause.Column{Name: param.QueryFields[0]}, "%"+param.Query+"%")
 		for _, field := range param.QueryFields[1:] {
-			sql = sql.Or("? LIKE ?", field, "%"+param.Query+"%")
+			cond = cond.Or("? LIKE ?", clause.Column{Name: field}, "%"+param.Query+"%")
 		}
+		sql = sql.Where(cond)
 	}
 	err = sql.Limit(limit).Offset(offset).Find(&data).Error
 	if err != nil {
@@ -153,7 +362,7 @@ func (dao *baseDAO[ModelT]) Paginate(db *gorm.DB, fields []string, preloads []st
 		PageSize: param.PageSize,
 	}
 
-	err = db.Model(&data).Count(&pageInfo.Total).Error
+	err = scoped.Model(&data).Count(&pageInfo.Total).Error
 
 	return
 }
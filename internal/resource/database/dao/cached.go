@@ -0,0 +1,368 @@
+package dao
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/cache"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// cacheCountersRegistry 各命名空间的缓存计数器，namespace -> *cacheCounters
+var cacheCountersRegistry sync.Map
+
+// CacheStats 缓存命中统计
+//
+//	author centonhuang
+//	update 2026-10-18 15:10:02
+type CacheStats struct {
+	Hits         uint64 `json:"hits"`
+	Misses       uint64 `json:"misses"`
+	NegativeHits uint64 `json:"negativeHits"`
+	Errors       uint64 `json:"errors"`
+}
+
+type cacheCounters struct {
+	hits         atomic.Uint64
+	misses       atomic.Uint64
+	negativeHits atomic.Uint64
+	errors       atomic.Uint64
+}
+
+func (c *cacheCounters) snapshot() CacheStats {
+	return CacheStats{
+		Hits:         c.hits.Load(),
+		Misses:       c.misses.Load(),
+		NegativeHits: c.negativeHits.Load(),
+		Errors:       c.errors.Load(),
+	}
+}
+
+// GetCacheStats 获取所有缓存DAO的命中统计
+//
+//	return map[string]CacheStats namespace -> 统计
+//	author centonhuang
+//	update 2026-10-18 15:10:08
+func GetCacheStats() map[string]CacheStats {
+	stats := map[string]CacheStats{}
+	cacheCountersRegistry.Range(func(namespace, counters any) bool {
+		stats[namespace.(string)] = counters.(*cacheCounters).snapshot()
+		return true
+	})
+	return stats
+}
+
+// CachedDAO 按ID读穿两级缓存的DAO
+//
+//	GetByID 未命中时通过 singleflight 合并回源，不存在的数据做短时负缓存；
+//	Create/Update/Delete 成功后删除缓存，处于事务中时提交后再删除一次。
+//	事务内读取、带预加载的查询与租户隔离模型直接查询数据库。
+//
+//	author centonhuang
+//	update 2026-10-18 15:34:02
+type CachedDAO[ModelT interface{}] struct {
+	baseDAO[ModelT]
+
+	cache       *cache.Namespace
+	negativeTTL time.Duration
+	group       singleflight.Group
+	counters    *cacheCounters
+}
+
+// newCachedDAO 创建缓存DAO，同一命名空间共享缓存与命中统计
+//
+//	param namespace string 缓存命名空间，缓存时间可通过 CacheNamespaceTTLs 覆盖
+//	return *CachedDAO[ModelT]
+//	author centonhuang
+//	update 2026-10-18 15:34:08
+func newCachedDAO[ModelT interface{}](namespace string) *CachedDAO[ModelT] {
+	counters, _ := cacheCountersRegistry.LoadOrStore(namespace, &cacheCounters{})
+	return &CachedDAO[ModelT]{
+		cache:       cache.NewNamespace(namespace, config.CacheDAOTTL),
+		negativeTTL: config.CacheDAONegativeTTL,
+		counters:    counters.(*cacheCounters),
+	}
+}
+
+// Stats 获取命中统计
+//
+//	receiver dao *CachedDAO[ModelT]
+//	return CacheStats
+//	author centonhuang
+//	update 2026-10-18 15:10:26
+func (dao *CachedDAO[ModelT]) Stats() CacheStats {
+	return dao.counters.snapshot()
+}
+
+// GetByID 使用ID查询指定数据，缓存中保存整行数据，命中时返回的字段可能多于 fields
+//
+//	receiver dao *CachedDAO[ModelT]
+//	param db *gorm.DB
+//	param id uint
+//	param fields []string
+//	param preloads []string
+//	return data *ModelT
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 15:34:14
+func (dao *CachedDAO[ModelT]) GetByID(db *gorm.DB, id uint, fields []string, preloads []string) (data *ModelT, err error) {
+	ctx := dao.context(db)
+	if !dao.cacheable() || len(preloads) > 0 || database.InTx(ctx) {
+		return dao.baseDAO.GetByID(db, id, fields, preloads)
+	}
+
+	key := strconv.FormatUint(uint64(id), 10)
+	content, ok, err := dao.cache.Get(ctx, key)
+	switch {
+	case err != nil:
+		// Redis 不可用时降级为直接查询数据库
+		dao.counters.errors.Add(1)
+		logger.WithCtx(ctx).Warn("[CachedDAO] failed to get cache", zap.String("namespace", dao.cache.Name()), zap.String("key", key), zap.Error(err))
+		return dao.baseDAO.GetByID(db, id, fields, preloads)
+	case ok && len(content) == 0:
+		dao.counters.negativeHits.Add(1)
+		return nil, gorm.ErrRecordNotFound
+	case ok:
+		if data, err = dao.decode(content); err == nil {
+			dao.counters.hits.Add(1)
+			return data, nil
+		}
+		dao.counters.errors.Add(1)
+		logger.WithCtx(ctx).Warn("[CachedDAO] failed to decode cache", zap.String("namespace", dao.cache.Name()), zap.String("key", key), zap.Error(err))
+	}
+
+	dao.counters.misses.Add(1)
+	value, err, _ := dao.group.Do(key, func() (interface{}, error) {
+		return dao.load(db, key, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if content = value.([]byte); len(content) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	// 每个调用方各自解码，避免共享同一个对象
+	return dao.decode(content)
+}
+
+// load 从主库回源并写入缓存，数据不存在时返回空内容
+func (dao *CachedDAO[ModelT]) load(db *gorm.DB, key string, id uint) ([]byte, error) {
+	ctx := dao.context(db)
+
+	// 回源读主库，避免副本延迟把旧数据写入缓存
+	data, err := dao.baseDAO.GetByID(db.Clauses(dbresolver.Write), id, nil, nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if dao.negativeTTL > 0 {
+			if err := dao.cache.Set(ctx, key, []byte{}, dao.negativeTTL); err != nil {
+				dao.counters.errors.Add(1)
+				logger.WithCtx(ctx).Warn("[CachedDAO] failed to set negative cache", zap.String("namespace", dao.cache.Name()), zap.String("key", key), zap.Error(err))
+			}
+		}
+		return []byte{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := dao.encode(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := dao.cache.Set(ctx, key, content, 0); err != nil {
+		dao.counters.errors.Add(1)
+		logger.WithCtx(ctx).Warn("[CachedDAO] failed to set cache", zap.String("namespace", dao.cache.Name()), zap.String("key", key), zap.Error(err))
+	}
+	return content, nil
+}
+
+// Create 创建数据并清除该ID可能存在的负缓存
+//
+//	receiver dao *CachedDAO[ModelT]
+//	param db *gorm.DB
+//	param data *ModelT
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 15:10:38
+func (dao *CachedDAO[ModelT]) Create(db *gorm.DB, data *ModelT) (err error) {
+	if err = dao.baseDAO.Create(db, data); err != nil {
+		return
+	}
+	dao.invalidate(db, data)
+	return
+}
+
+// Update 使用ID更新数据并清除缓存
+//
+//	receiver dao *CachedDAO[ModelT]
+//	param db *gorm.DB
+//	param data *ModelT
+//	param info map[string]interface{}
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 15:10:44
+func (dao *CachedDAO[ModelT]) Update(db *gorm.DB, data *ModelT, info map[string]interface{}) (err error) {
+	if err = dao.baseDAO.Update(db, data, info); err != nil {
+		return
+	}
+	dao.invalidate(db, data)
+	return
+}
+
+// UpdateWithVersion 带版本号条件更新数据，成功后清除缓存
+//
+//	receiver dao *CachedDAO[ModelT]
+//	param db *gorm.DB
+//	param data *ModelT
+//	param version uint
+//	param info map[string]interface{}
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 15:10:50
+func (dao *CachedDAO[ModelT]) UpdateWithVersion(db *gorm.DB, data *ModelT, version uint, info map[string]interface{}) (err error) {
+	if err = dao.baseDAO.UpdateWithVersion(db, data, version, info); err != nil {
+		return
+	}
+	dao.invalidate(db, data)
+	return
+}
+
+// Delete 删除数据并清除缓存
+//
+//	receiver dao *CachedDAO[ModelT]
+//	param db *gorm.DB
+//	param data *ModelT
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 15:10:56
+func (dao *CachedDAO[ModelT]) Delete(db *gorm.DB, data *ModelT) (err error) {
+	if err = dao.baseDAO.Delete(db, data); err != nil {
+		return
+	}
+	dao.invalidate(db, data)
+	return
+}
+
+// BatchDelete 批量删除数据并清除缓存
+//
+//	receiver dao *CachedDAO[ModelT]
+//	param db *gorm.DB
+//	param data *[]ModelT
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 15:11:02
+func (dao *CachedDAO[ModelT]) BatchDelete(db *gorm.DB, data *[]ModelT) (err error) {
+	if err = dao.baseDAO.BatchDelete(db, data); err != nil {
+		return
+	}
+	items := make([]*ModelT, 0, len(*data))
+	for i := range *data {
+		items = append(items, &(*data)[i])
+	}
+	dao.invalidate(db, items...)
+	return
+}
+
+// Invalidate 清除指定ID的缓存，用于绕过DAO修改数据后的手动失效
+//
+//	receiver dao *CachedDAO[ModelT]
+//	param ctx context.Context
+//	param ids ...uint
+//	return error
+//	author centonhuang
+//	update 2026-10-18 15:11:08
+func (dao *CachedDAO[ModelT]) Invalidate(ctx context.Context, ids ...uint) error {
+	if !dao.cacheable() {
+		return nil
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, strconv.FormatUint(uint64(id), 10))
+	}
+	return dao.cache.Delete(ctx, keys...)
+}
+
+// invalidate 写入成功后清除缓存，处于事务中时提交后再清除一次，防止事务期间其他请求回填旧数据
+//
+//	缓存清除失败只记录日志，不影响已成功的写入，旧数据最长保留 ttl
+func (dao *CachedDAO[ModelT]) invalidate(db *gorm.DB, items ...*ModelT) {
+	if !dao.cacheable() {
+		return
+	}
+
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		if id, ok := dao.primaryKey(db, item); ok {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	invalidate := func(ctx context.Context) error {
+		if err := dao.Invalidate(ctx, ids...); err != nil {
+			dao.counters.errors.Add(1)
+			logger.WithCtx(ctx).Warn("[CachedDAO] failed to invalidate cache", zap.String("namespace", dao.cache.Name()), zap.Uints("ids", ids), zap.Error(err))
+		}
+		return nil
+	}
+
+	ctx := dao.context(db)
+	_ = invalidate(ctx)
+	if database.InTx(ctx) {
+		_ = database.AfterCommit(ctx, invalidate)
+	}
+}
+
+// cacheable 缓存时间不为正或模型开启租户隔离时不使用缓存
+func (dao *CachedDAO[ModelT]) cacheable() bool {
+	return dao.cache.Enabled() && !model.IsTenantScoped(new(ModelT))
+}
+
+func (dao *CachedDAO[ModelT]) primaryKey(db *gorm.DB, data *ModelT) (uint, bool) {
+	if data == nil {
+		return 0, false
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(data); err != nil || stmt.Schema.PrioritizedPrimaryField == nil {
+		return 0, false
+	}
+
+	value, zero := stmt.Schema.PrioritizedPrimaryField.ValueOf(dao.context(db), reflect.ValueOf(data).Elem())
+	id, ok := value.(uint)
+	return id, ok && !zero
+}
+
+// encode 使用 gob 编码整行数据，json:"-" 的字段同样会被缓存
+func (dao *CachedDAO[ModelT]) encode(data *ModelT) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (dao *CachedDAO[ModelT]) decode(content []byte) (*ModelT, error) {
+	data := new(ModelT)
+	if err := gob.NewDecoder(bytes.NewReader(content)).Decode(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
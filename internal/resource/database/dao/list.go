@@ -0,0 +1,390 @@
+package dao
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+
+	idField = "id"
+
+	// likeEscape LIKE 转义字符，三种方言都支持显式 ESCAPE
+	likeEscape = "!"
+)
+
+// ErrInvalidListParam 列表参数不合法：字段或操作符不在白名单、值无法解析、游标无效
+//
+//	update 2026-10-18 14:40:02
+var ErrInvalidListParam = errors.New("invalid list param")
+
+// FilterOp 过滤操作符
+//
+//	author centonhuang
+//	update 2026-10-18 14:40:08
+type FilterOp string
+
+const (
+	// FilterOpEq 等于
+	FilterOpEq FilterOp = "eq"
+	// FilterOpNe 不等于
+	FilterOpNe FilterOp = "ne"
+	// FilterOpGt 大于
+	FilterOpGt FilterOp = "gt"
+	// FilterOpGte 大于等于
+	FilterOpGte FilterOp = "gte"
+	// FilterOpLt 小于
+	FilterOpLt FilterOp = "lt"
+	// FilterOpLte 小于等于
+	FilterOpLte FilterOp = "lte"
+	// FilterOpContains 包含，忽略大小写
+	FilterOpContains FilterOp = "contains"
+	// FilterOpPrefix 前缀匹配
+	FilterOpPrefix FilterOp = "prefix"
+	// FilterOpIn 属于逗号分隔的取值之一
+	FilterOpIn FilterOp = "in"
+)
+
+// FieldType 字段值类型，决定过滤值与游标值的解析方式
+//
+//	author centonhuang
+//	update 2026-10-18 14:40:14
+type FieldType int
+
+const (
+	// FieldTypeString 字符串
+	FieldTypeString FieldType = iota
+	// FieldTypeInt 整数
+	FieldTypeInt
+	// FieldTypeTime 时间，格式为 RFC3339
+	FieldTypeTime
+)
+
+// FieldSpec 可查询字段的白名单定义
+//
+//	author centonhuang
+//	update 2026-10-18 14:40:20
+type FieldSpec struct {
+	Column   string
+	Type     FieldType
+	Ops      []FilterOp
+	Sortable bool
+}
+
+// ListSpec 模型的列表查询白名单，键为对外暴露的字段名
+//
+//	author centonhuang
+//	update 2026-10-18 14:40:26
+type ListSpec struct {
+	Fields      map[string]FieldSpec
+	DefaultSort string
+}
+
+// Filter 单个过滤条件
+//
+//	author centonhuang
+//	update 2026-10-18 14:40:32
+type Filter struct {
+	Field string
+	Op    FilterOp
+	Value string
+}
+
+// ListParam 游标分页查询参数
+//
+//	author centonhuang
+//	update 2026-10-18 14:40:38
+type ListParam struct {
+	Filters []Filter
+	// Sort 排序字段，- 前缀表示倒序，为空使用 ListSpec.DefaultSort
+	Sort   string
+	Cursor string
+	Limit  int
+}
+
+// CursorPageInfo 游标分页信息
+//
+//	author centonhuang
+//	update 2026-10-18 14:40:44
+type CursorPageInfo struct {
+	Limit      int
+	HasNext    bool
+	HasPrev    bool
+	NextCursor string
+	PrevCursor string
+}
+
+// cursor 游标内容，编码后对调用方不透明，携带排序方式以拒绝跨排序复用
+type cursor struct {
+	Sort     string `json:"s"`
+	Value    string `json:"v"`
+	ID       uint   `json:"i"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// List 按过滤条件与排序做游标分页(keyset)，排序字段相同时以ID作为次序，翻页结果在并发写入下保持稳定
+//
+//	param dao *baseDAO[ModelT]
+//	param db *gorm.DB
+//	param fields []string
+//	param preloads []string
+//	param spec *ListSpec
+//	param param *ListParam
+//	return data []*ModelT
+//	return pageInfo *CursorPageInfo
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 14:40:50
+func (dao *baseDAO[ModelT]) List(db *gorm.DB, fields, preloads []string, spec *ListSpec, param *ListParam) (data []*ModelT, pageInfo *CursorPageInfo, err error) {
+	sql, err := dao.tenantScope(db)
+	if err != nil {
+		return
+	}
+
+	limit := param.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	limit = min(limit, maxListLimit)
+
+	sortKey := param.Sort
+	if sortKey == "" {
+		sortKey = spec.DefaultSort
+	}
+	sortName, desc := strings.TrimPrefix(sortKey, "-"), strings.HasPrefix(sortKey, "-")
+	sortField, ok := spec.Fields[sortName]
+	if !ok || !sortField.Sortable {
+		return nil, nil, fmt.Errorf("%w: field %q is not sortable", ErrInvalidListParam, sortName)
+	}
+
+	for _, filter := range param.Filters {
+		expr, err := spec.filterExpr(filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		sql = sql.Where(expr)
+	}
+
+	var cur *cursor
+	if param.Cursor != "" {
+		if cur, err = decodeCursor(param.Cursor, sortKey); err != nil {
+			return
+		}
+		value, err := parseFieldValue(sortField, cur.Value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: invalid cursor", ErrInvalidListParam)
+		}
+		// 倒序向后翻页与正序向前翻页都取 (column, id) 小于游标的数据
+		sql = sql.Where(keysetExpr(sortField.Column, value, cur.ID, desc != cur.Backward))
+	}
+
+	backward := cur != nil && cur.Backward
+	orderDesc := desc != backward
+	sql = sql.Order(clause.OrderByColumn{Column: clause.Column{Table: clause.CurrentTable, Name: sortField.Column}, Desc: orderDesc})
+	if sortField.Column != idField {
+		sql = sql.Order(clause.OrderByColumn{Column: clause.Column{Table: clause.CurrentTable, Name: idField}, Desc: orderDesc})
+	}
+
+	if len(fields) > 0 {
+		fields = withColumns(fields, sortField.Column, idField)
+	}
+	sql = sql.Select(fields)
+	for _, preload := range preloads {
+		sql = sql.Preload(preload)
+	}
+
+	// 多取一条判断是否还有下一页
+	if err = sql.Limit(limit + 1).Find(&data).Error; err != nil {
+		return
+	}
+
+	hasMore := len(data) > limit
+	if hasMore {
+		data = data[:limit]
+	}
+	if backward {
+		slices.Reverse(data)
+	}
+
+	pageInfo = &CursorPageInfo{Limit: limit}
+	if backward {
+		pageInfo.HasPrev, pageInfo.HasNext = hasMore, true
+	} else {
+		pageInfo.HasPrev, pageInfo.HasNext = cur != nil, hasMore
+	}
+	if len(data) == 0 {
+		return
+	}
+
+	if pageInfo.HasNext {
+		if pageInfo.NextCursor, err = dao.encodeCursor(db, data[len(data)-1], sortKey, sortField, false); err != nil {
+			return
+		}
+	}
+	if pageInfo.HasPrev {
+		if pageInfo.PrevCursor, err = dao.encodeCursor(db, data[0], sortKey, sortField, true); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (dao *baseDAO[ModelT]) encodeCursor(db *gorm.DB, item *ModelT, sortKey string, sortField FieldSpec, backward bool) (string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(item); err != nil {
+		return "", err
+	}
+
+	itemValue := reflect.ValueOf(item).Elem()
+	sortValue, _ := stmt.Schema.LookUpField(sortField.Column).ValueOf(dao.context(db), itemValue)
+	idValue, _ := stmt.Schema.LookUpField(idField).ValueOf(dao.context(db), itemValue)
+
+	id, ok := idValue.(uint)
+	if !ok {
+		return "", fmt.Errorf("cursor pagination requires uint primary key, got %T", idValue)
+	}
+
+	content, err := sonic.Marshal(cursor{
+		Sort:     sortKey,
+		Value:    formatFieldValue(sortValue),
+		ID:       id,
+		Backward: backward,
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(content), nil
+}
+
+func decodeCursor(encoded, sortKey string) (*cursor, error) {
+	content, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrInvalidListParam)
+	}
+
+	cur := &cursor{}
+	if err := sonic.Unmarshal(content, cur); err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrInvalidListParam)
+	}
+	if cur.Sort != sortKey {
+		return nil, fmt.Errorf("%w: cursor was issued for sort %q", ErrInvalidListParam, cur.Sort)
+	}
+	return cur, nil
+}
+
+// keysetExpr less 为 true 时取 (column, id) 小于游标的数据，否则取大于游标的数据
+func keysetExpr(column string, value interface{}, id uint, less bool) clause.Expression {
+	sortColumn := clause.Column{Table: clause.CurrentTable, Name: column}
+	idColumn := clause.Column{Table: clause.CurrentTable, Name: idField}
+
+	// 按主键排序时主键本身即唯一，无需追加比较
+	if column == idField {
+		if less {
+			return clause.Lt{Column: idColumn, Value: id}
+		}
+		return clause.Gt{Column: idColumn, Value: id}
+	}
+	if less {
+		return clause.Or(
+			clause.Lt{Column: sortColumn, Value: value},
+			clause.And(clause.Eq{Column: sortColumn, Value: value}, clause.Lt{Column: idColumn, Value: id}),
+		)
+	}
+	return clause.Or(
+		clause.Gt{Column: sortColumn, Value: value},
+		clause.And(clause.Eq{Column: sortColumn, Value: value}, clause.Gt{Column: idColumn, Value: id}),
+	)
+}
+
+func (spec *ListSpec) filterExpr(filter Filter) (clause.Expression, error) {
+	field, ok := spec.Fields[filter.Field]
+	if !ok || !slices.Contains(field.Ops, filter.Op) {
+		return nil, fmt.Errorf("%w: filter %s[%s] is not allowed", ErrInvalidListParam, filter.Field, filter.Op)
+	}
+	column := clause.Column{Table: clause.CurrentTable, Name: field.Column}
+
+	switch filter.Op {
+	case FilterOpContains, FilterOpPrefix:
+		pattern := escapeLike(strings.ToLower(filter.Value)) + "%"
+		if filter.Op == FilterOpContains {
+			pattern = "%" + pattern
+		}
+		return clause.Expr{SQL: "LOWER(?) LIKE ? ESCAPE '" + likeEscape + "'", Vars: []interface{}{column, pattern}}, nil
+	case FilterOpIn:
+		var values []interface{}
+		for _, raw := range strings.Split(filter.Value, ",") {
+			value, err := parseFieldValue(field, strings.TrimSpace(raw))
+			if err != nil {
+				return nil, fmt.Errorf("%w: filter %s[%s]: %w", ErrInvalidListParam, filter.Field, filter.Op, err)
+			}
+			values = append(values, value)
+		}
+		return clause.IN{Column: column, Values: values}, nil
+	}
+
+	value, err := parseFieldValue(field, filter.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: filter %s[%s]: %w", ErrInvalidListParam, filter.Field, filter.Op, err)
+	}
+
+	switch filter.Op {
+	case FilterOpEq:
+		return clause.Eq{Column: column, Value: value}, nil
+	case FilterOpNe:
+		return clause.Neq{Column: column, Value: value}, nil
+	case FilterOpGt:
+		return clause.Gt{Column: column, Value: value}, nil
+	case FilterOpGte:
+		return clause.Gte{Column: column, Value: value}, nil
+	case FilterOpLt:
+		return clause.Lt{Column: column, Value: value}, nil
+	case FilterOpLte:
+		return clause.Lte{Column: column, Value: value}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown filter op %q", ErrInvalidListParam, filter.Op)
+	}
+}
+
+func parseFieldValue(field FieldSpec, raw string) (interface{}, error) {
+	switch field.Type {
+	case FieldTypeInt:
+		return strconv.ParseInt(raw, 10, 64)
+	case FieldTypeTime:
+		return time.Parse(time.RFC3339Nano, raw)
+	default:
+		return raw, nil
+	}
+}
+
+func formatFieldValue(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(value)
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_").Replace(value)
+}
+
+func withColumns(fields []string, columns ...string) []string {
+	result := slices.Clone(fields)
+	for _, column := range columns {
+		if !slices.Contains(result, column) {
+			result = append(result, column)
+		}
+	}
+	return result
+}
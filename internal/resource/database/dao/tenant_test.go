@@ -0,0 +1,229 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/dbtest"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
+	"gorm.io/gorm"
+)
+
+const (
+	tenantA uint = 1
+	tenantB uint = 2
+)
+
+// tenantItem 仅用于测试的租户隔离模型
+type tenantItem struct {
+	model.BaseModel
+	model.TenantModel
+	model.VersionModel
+	Name string `gorm:"column:name;not null"`
+	Note string `gorm:"column:note;not null;default:''"`
+}
+
+type tenantItemDAO struct {
+	baseDAO[tenantItem]
+}
+
+// tenantFixture 两个租户各自拥有的数据
+type tenantFixture struct {
+	db    *gorm.DB
+	dao   *tenantItemDAO
+	ctxA  context.Context
+	ctxB  context.Context
+	itemA *tenantItem
+	itemB *tenantItem
+}
+
+func newTenantFixture(t *testing.T) *tenantFixture {
+	t.Helper()
+
+	db := dbtest.New(t)
+	if err := db.AutoMigrate(&tenantItem{}); err != nil {
+		t.Fatalf("migrate tenant items: %v", err)
+	}
+
+	f := &tenantFixture{
+		db:    db,
+		dao:   &tenantItemDAO{},
+		ctxA:  database.WithTenant(context.Background(), tenantA),
+		ctxB:  database.WithTenant(context.Background(), tenantB),
+		itemA: &tenantItem{Name: "alpha", Note: "shared"},
+		itemB: &tenantItem{Name: "beta", Note: "shared"},
+	}
+	if err := f.dao.Create(db.WithContext(f.ctxA), f.itemA); err != nil {
+		t.Fatalf("create item of tenant A: %v", err)
+	}
+	if err := f.dao.Create(db.WithContext(f.ctxB), f.itemB); err != nil {
+		t.Fatalf("create item of tenant B: %v", err)
+	}
+	if f.itemA.TenantID != tenantA || f.itemB.TenantID != tenantB {
+		t.Fatalf("tenant id not filled from context: A=%d B=%d", f.itemA.TenantID, f.itemB.TenantID)
+	}
+	return f
+}
+
+// reload 跳过租户隔离读取数据库中的真实数据
+func (f *tenantFixture) reload(t *testing.T, id uint) *tenantItem {
+	t.Helper()
+
+	var item tenantItem
+	if err := f.db.WithContext(database.WithoutTenantScope(context.Background())).Where("id = ?", id).First(&item).Error; err != nil {
+		t.Fatalf("reload item %d: %v", id, err)
+	}
+	return &item
+}
+
+func TestTenantScopeGetByID(t *testing.T) {
+	f := newTenantFixture(t)
+
+	item, err := f.dao.GetByID(f.db.WithContext(f.ctxA), f.itemA.ID, []string{"*"}, nil)
+	if err != nil || item.Name != "alpha" {
+		t.Fatalf("get own item: item=%v err=%v", item, err)
+	}
+
+	if _, err := f.dao.GetByID(f.db.WithContext(f.ctxA), f.itemB.ID, []string{"*"}, nil); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("get item of another tenant: want ErrRecordNotFound, got %v", err)
+	}
+}
+
+func TestTenantScopeBatchGetByIDs(t *testing.T) {
+	f := newTenantFixture(t)
+
+	items, err := f.dao.BatchGetByIDs(f.db.WithContext(f.ctxB), []uint{f.itemA.ID, f.itemB.ID}, []string{"*"}, nil)
+	if err != nil {
+		t.Fatalf("batch get: %v", err)
+	}
+	if len(*items) != 1 || (*items)[0].ID != f.itemB.ID {
+		t.Fatalf("batch get returned items of other tenants: %+v", *items)
+	}
+}
+
+func TestTenantScopePaginate(t *testing.T) {
+	f := newTenantFixture(t)
+
+	// 两个租户的数据都能匹配 OR 条件，成组后仍只能查到本租户的数据
+	param := &PaginateParam{
+		PageParam:  &PageParam{Page: 1, PageSize: 10},
+		QueryParam: &QueryParam{Query: "a", QueryFields: []string{"name", "note"}},
+	}
+	items, pageInfo, err := f.dao.Paginate(f.db.WithContext(f.ctxA), []string{"*"}, nil, param)
+	if err != nil {
+		t.Fatalf("paginate: %v", err)
+	}
+	if len(*items) != 1 || (*items)[0].ID != f.itemA.ID {
+		t.Fatalf("paginate returned items of other tenants: %+v", *items)
+	}
+	if pageInfo.Total != 1 {
+		t.Fatalf("paginate total counts other tenants: %d", pageInfo.Total)
+	}
+
+	// 查询字段作为列名匹配
+	param.QueryParam = &QueryParam{Query: "zzz", QueryFields: []string{"name", "note"}}
+	if items, _, err = f.dao.Paginate(f.db.WithContext(f.ctxA), []string{"*"}, nil, param); err != nil || len(*items) != 0 {
+		t.Fatalf("paginate with unmatched query: items=%v err=%v", items, err)
+	}
+}
+
+func TestTenantScopeUpdate(t *testing.T) {
+	f := newTenantFixture(t)
+
+	target := &tenantItem{BaseModel: model.BaseModel{ID: f.itemB.ID}}
+	if err := f.dao.Update(f.db.WithContext(f.ctxA), target, map[string]interface{}{"name": "hijacked"}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if item := f.reload(t, f.itemB.ID); item.Name != "beta" {
+		t.Fatalf("update changed item of another tenant: %+v", item)
+	}
+
+	own := &tenantItem{BaseModel: model.BaseModel{ID: f.itemA.ID}}
+	if err := f.dao.Update(f.db.WithContext(f.ctxA), own, map[string]interface{}{"name": "alpha2"}); err != nil {
+		t.Fatalf("update own item: %v", err)
+	}
+	if item := f.reload(t, f.itemA.ID); item.Name != "alpha2" {
+		t.Fatalf("update own item not applied: %+v", item)
+	}
+
+	if err := f.dao.Update(f.db.WithContext(f.ctxA), own, map[string]interface{}{"tenant_id": tenantB}); !errors.Is(err, ErrTenantMismatch) {
+		t.Fatalf("moving item to another tenant: want ErrTenantMismatch, got %v", err)
+	}
+}
+
+func TestTenantScopeUpdateWithVersion(t *testing.T) {
+	f := newTenantFixture(t)
+
+	target := &tenantItem{BaseModel: model.BaseModel{ID: f.itemB.ID}}
+	err := f.dao.UpdateWithVersion(f.db.WithContext(f.ctxA), target, f.itemB.Version, map[string]interface{}{"name": "hijacked"})
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("update with version on another tenant: want ErrRecordNotFound, got %v", err)
+	}
+	if item := f.reload(t, f.itemB.ID); item.Name != "beta" || item.Version != f.itemB.Version {
+		t.Fatalf("update with version changed item of another tenant: %+v", item)
+	}
+}
+
+func TestTenantScopeDelete(t *testing.T) {
+	f := newTenantFixture(t)
+
+	if err := f.dao.Delete(f.db.WithContext(f.ctxA), &tenantItem{BaseModel: model.BaseModel{ID: f.itemB.ID}}); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	f.reload(t, f.itemB.ID)
+
+	batch := []tenantItem{{BaseModel: model.BaseModel{ID: f.itemA.ID}}, {BaseModel: model.BaseModel{ID: f.itemB.ID}}}
+	if err := f.dao.BatchDelete(f.db.WithContext(f.ctxA), &batch); err != nil {
+		t.Fatalf("batch delete: %v", err)
+	}
+	f.reload(t, f.itemB.ID)
+
+	var remaining int64
+	if err := f.db.Model(&tenantItem{}).Where("id = ?", f.itemA.ID).Count(&remaining).Error; err != nil || remaining != 0 {
+		t.Fatalf("batch delete should remove own item: remaining=%d err=%v", remaining, err)
+	}
+}
+
+func TestTenantScopeCreateMismatch(t *testing.T) {
+	f := newTenantFixture(t)
+
+	item := &tenantItem{TenantModel: model.TenantModel{TenantID: tenantB}, Name: "gamma"}
+	if err := f.dao.Create(f.db.WithContext(f.ctxA), item); !errors.Is(err, ErrTenantMismatch) {
+		t.Fatalf("create for another tenant: want ErrTenantMismatch, got %v", err)
+	}
+}
+
+func TestTenantScopeRequired(t *testing.T) {
+	f := newTenantFixture(t)
+	db := f.db.WithContext(context.Background())
+
+	if err := f.dao.Create(db, &tenantItem{Name: "gamma"}); !errors.Is(err, ErrTenantRequired) {
+		t.Fatalf("create without tenant: want ErrTenantRequired, got %v", err)
+	}
+	if _, err := f.dao.GetByID(db, f.itemA.ID, []string{"*"}, nil); !errors.Is(err, ErrTenantRequired) {
+		t.Fatalf("get without tenant: want ErrTenantRequired, got %v", err)
+	}
+	if _, err := f.dao.BatchGetByIDs(db, []uint{f.itemA.ID}, []string{"*"}, nil); !errors.Is(err, ErrTenantRequired) {
+		t.Fatalf("batch get without tenant: want ErrTenantRequired, got %v", err)
+	}
+	if err := f.dao.Delete(db, &tenantItem{BaseModel: model.BaseModel{ID: f.itemA.ID}}); !errors.Is(err, ErrTenantRequired) {
+		t.Fatalf("delete without tenant: want ErrTenantRequired, got %v", err)
+	}
+}
+
+func TestTenantScopeSkipped(t *testing.T) {
+	f := newTenantFixture(t)
+	db := f.db.WithContext(database.WithoutTenantScope(context.Background()))
+
+	items, err := f.dao.BatchGetByIDs(db, []uint{f.itemA.ID, f.itemB.ID}, []string{"*"}, nil)
+	if err != nil || len(*items) != 2 {
+		t.Fatalf("batch get without tenant scope: items=%v err=%v", items, err)
+	}
+
+	item := &tenantItem{TenantModel: model.TenantModel{TenantID: tenantB}, Name: "gamma"}
+	if err := f.dao.Create(db, item); err != nil || item.TenantID != tenantB {
+		t.Fatalf("create without tenant scope: item=%+v err=%v", item, err)
+	}
+}
@@ -5,12 +5,21 @@ import (
 	"gorm.io/gorm"
 )
 
-// UserDAO 用户DAO
+// UserDAO 用户DAO，按ID的查询经过Redis缓存
 //
 //	author centonhuang
-//	update 2024-10-17 02:30:24
+//	update 2026-10-18 15:12:02
 type UserDAO struct {
-	baseDAO[model.User]
+	*CachedDAO[model.User]
+}
+
+// NewUserDAO 创建用户DAO，多次创建的实例共享同一缓存命名空间
+//
+//	return *UserDAO
+//	author centonhuang
+//	update 2026-10-18 18:02:08
+func NewUserDAO() *UserDAO {
+	return &UserDAO{CachedDAO: newCachedDAO[model.User]("user")}
 }
 
 // GetByEmail 通过邮箱获取用户
@@ -24,7 +33,11 @@ type UserDAO struct {
 //	author centonhuang
 //	update 2024-10-17 05:08:00
 func (dao *UserDAO) GetByEmail(db *gorm.DB, email string, fields, preloads []string) (user *model.User, err error) {
-	sql := db.Select(fields)
+	sql, err := dao.tenantScope(db)
+	if err != nil {
+		return
+	}
+	sql = sql.Select(fields)
 	for _, preload := range preloads {
 		sql = sql.Preload(preload)
 	}
@@ -43,10 +56,29 @@ func (dao *UserDAO) GetByEmail(db *gorm.DB, email string, fields, preloads []str
 //	author centonhuang
 //	update 2024-10-17 05:18:46
 func (dao *UserDAO) GetByName(db *gorm.DB, name string, fields, preloads []string) (user *model.User, err error) {
-	sql := db.Select(fields)
+	sql, err := dao.tenantScope(db)
+	if err != nil {
+		return
+	}
+	sql = sql.Select(fields)
 	for _, preload := range preloads {
 		sql = sql.Preload(preload)
 	}
 	err = sql.Where(model.User{Name: name}).First(&user).Error
 	return
 }
+
+// UserListSpec 用户列表可过滤与排序的字段
+//
+//	update 2026-10-18 14:42:02
+var UserListSpec = &ListSpec{
+	Fields: map[string]FieldSpec{
+		"id":         {Column: "id", Type: FieldTypeInt, Ops: []FilterOp{FilterOpEq, FilterOpIn}, Sortable: true},
+		"name":       {Column: "name", Ops: []FilterOp{FilterOpEq, FilterOpContains, FilterOpPrefix}, Sortable: true},
+		"email":      {Column: "email", Ops: []FilterOp{FilterOpEq, FilterOpContains}},
+		"permission": {Column: "permission", Ops: []FilterOp{FilterOpEq, FilterOpNe, FilterOpIn}},
+		"created_at": {Column: "created_at", Type: FieldTypeTime, Ops: []FilterOp{FilterOpGt, FilterOpGte, FilterOpLt, FilterOpLte}, Sortable: true},
+		"last_login": {Column: "last_login", Type: FieldTypeTime, Ops: []FilterOp{FilterOpGt, FilterOpGte, FilterOpLt, FilterOpLte}, Sortable: true},
+	},
+	DefaultSort: "-id",
+}
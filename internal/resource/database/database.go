@@ -5,18 +5,21 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/health"
 	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/tracing"
 	"go.uber.org/zap"
 
 	"github.com/samber/lo"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 )
 
 // DB undefined 数据库连接
@@ -24,64 +27,123 @@ import (
 //	update 2024-09-16 01:24:51
 var db *gorm.DB
 
-// GetDBInstance 获取数据库实例
+// GetDBInstance 获取数据库实例，上下文处于 WithTx 中时返回该事务；
+// 配置了只读副本时查询默认走副本，上下文经 WithPrimary 标记后走主库
 //
 //	return *gorm.DB
 //	author centonhuang
-//	update 2024-10-17 08:35:47
+//	update 2026-10-18 14:10:38
 func GetDBInstance(ctx context.Context) *gorm.DB {
-	return db.WithContext(ctx)
+	if state, ok := txFromContext(ctx); ok {
+		return state.tx.WithContext(ctx)
+	}
+
+	instance := db.WithContext(ctx)
+	if IsPrimaryForced(ctx) {
+		instance = instance.Clauses(dbresolver.Write).Session(&gorm.Session{})
+	}
+	return instance
 }
 
 // GetDBInstanceFromFiber 从GoFiber上下文获取数据库实例
 //
 //	return *gorm.DB
 //	author centonhuang
-//	update 2024-10-17 08:35:47
+//	update 2026-10-18 17:46:38
 func GetDBInstanceFromFiber(c *fiber.Ctx) *gorm.DB {
-	return db.WithContext(c.Context())
+	return GetDBInstance(c.UserContext())
 }
 
-// InitDatabase 初始化数据库
+// InitDatabase 初始化数据库，按 DATABASE_DRIVER 选择 postgres / mysql / sqlite
 //
 //	author centonhuang
-//	update 2024-09-22 10:04:36
+//	update 2026-10-18 17:51:02
 func InitDatabase() {
-	var dialector gorm.Dialector
-	var dbHost, dbPort, dbName string
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=Asia/Shanghai",
-		config.PostgresHost, config.PostgresUser, config.PostgresPassword,
-		config.PostgresDatabase, config.PostgresPort, config.PostgresSSLMode)
-	dialector = postgres.Open(dsn)
-	dbHost, dbPort, dbName = config.PostgresHost, config.PostgresPort, config.PostgresDatabase
-
-	// 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-	// 		config.MysqlUser, config.MysqlPassword, config.MysqlHost, config.MysqlPort, config.MysqlDatabase)
-	// 	dialector = mysql.New(mysql.Config{
-	// 		DSN:               dsn,
-	// 		DefaultStringSize: 256,
-	// 	})
-	// 	dbHost, dbPort, dbName = config.MysqlHost, config.MysqlPort, config.MysqlDatabase
-
-	db = lo.Must(gorm.Open(dialector, &gorm.Config{
-		DryRun:         false, // 只生成SQL不运行
-		TranslateError: true,
-		Logger: &GormLoggerAdapter{
-			LogLevel: gormlogger.Info, // Info级别
-		},
-	}))
+	dialector, info := lo.Must2(newDialector(config.DatabaseDriver))
+	db = lo.Must(openDB(dialector))
+	lo.Must0(db.Use(&tracing.GormPlugin{}))
+
+	if config.PostgresTenantRLS {
+		if config.DatabaseDriver == DriverPostgres {
+			lo.Must0(db.Use(&tenantRLSPlugin{}))
+		} else {
+			logger.Logger().Warn("[Database] Tenant RLS is only supported by postgres, ignored", zap.String("driver", config.DatabaseDriver))
+		}
+	}
 
 	sqlDB := lo.Must(db.DB())
-
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(5 * time.Hour)
+	configurePool(sqlDB, config.DatabaseDriver)
+	lo.Must0(sqlDB.Ping())
+	health.Register("database", sqlDB.PingContext)
+
+	if len(config.DatabaseReplicas) > 0 {
+		if config.DatabaseDriver == DriverSQLite {
+			logger.Logger().Warn("[Database] Read replicas are not supported by sqlite, ignored")
+		} else {
+			lo.Must0(registerReplicas(db, config.DatabaseDriver, config.DatabaseReplicas))
+			logger.Logger().Info("[Database] Read replicas registered", zap.Strings("replicas", config.DatabaseReplicas))
+		}
+	}
 
 	logger.Logger().Info("[Database] Connected to database",
-		zap.String("host", dbHost),
-		zap.String("port", dbPort),
-		zap.String("database", dbName))
+		zap.String("driver", config.DatabaseDriver),
+		zap.String("host", info.host),
+		zap.String("port", info.port),
+		zap.String("database", info.database))
+}
+
+// CloseDatabase 停止副本健康检查并关闭副本与主库连接池，等待执行中的查询结束，直到 ctx 结束
+//
+//	param ctx context.Context
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:41:08
+func CloseDatabase(ctx context.Context) error {
+	if db == nil {
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		var errs []error
+		if replicas != nil {
+			errs = append(errs, replicas.close())
+		}
+		sqlDB, err := db.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		done <- errors.Join(append(errs, err)...)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SwapDBInstance 替换全局数据库实例并返回原实例，用于单元测试注入SQLite内存数据库
+//
+//	param instance *gorm.DB
+//	return previous *gorm.DB
+//	author centonhuang
+//	update 2026-10-18 13:36:08
+func SwapDBInstance(instance *gorm.DB) (previous *gorm.DB) {
+	previous, db = db, instance
+	return
+}
+
+func openDB(dialector gorm.Dialector) (*gorm.DB, error) {
+	return gorm.Open(dialector, &gorm.Config{
+		DryRun:               false, // 只生成SQL不运行
+		TranslateError:       true,
+		DisableAutomaticPing: true, // 由调用方在设置连接池后主动Ping
+		Logger: &GormLoggerAdapter{
+			LogLevel: gormlogger.Info, // Info级别
+		},
+	})
 }
 
 // GormLoggerAdapter 实现gorm的logger接口,使用zap输出SQL日志
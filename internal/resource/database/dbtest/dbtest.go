@@ -0,0 +1,45 @@
+// Package dbtest 为单元测试提供执行过全部迁移的SQLite内存数据库，DAO与Service无需启动数据库服务即可测试
+//
+//	update 2026-10-18 13:40:02
+package dbtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/migration"
+	"gorm.io/gorm"
+)
+
+// New 创建执行过全部迁移的SQLite内存数据库，并替换 database 包的全局实例，测试结束后自动还原并关闭
+//
+//	param tb testing.TB
+//	return *gorm.DB
+//	author centonhuang
+//	update 2026-10-18 13:40:08
+func New(tb testing.TB) *gorm.DB {
+	tb.Helper()
+
+	db, err := database.OpenSQLiteMemory()
+	if err != nil {
+		tb.Fatalf("open sqlite memory database: %v", err)
+	}
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		tb.Fatalf("create migrator: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		tb.Fatalf("migrate sqlite memory database: %v", err)
+	}
+
+	previous := database.SwapDBInstance(db)
+	tb.Cleanup(func() {
+		database.SwapDBInstance(previous)
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return db
+}
@@ -0,0 +1,159 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/glebarez/sqlite"
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	// DriverPostgres Postgres驱动
+	DriverPostgres = "postgres"
+
+	// DriverMySQL MySQL驱动
+	DriverMySQL = "mysql"
+
+	// DriverSQLite SQLite驱动，纯Go实现，无需CGO
+	DriverSQLite = "sqlite"
+
+	sqliteMemoryPath = ":memory:"
+)
+
+// ErrUnsupportedDriver 不支持的数据库驱动
+//
+//	update 2026-10-18 13:35:02
+var ErrUnsupportedDriver = errors.New("unsupported database driver")
+
+var memoryDBCounter atomic.Int64
+
+// connInfo 连接信息，仅用于日志
+type connInfo struct {
+	host, port, database string
+}
+
+// newDialector 按驱动构造主库的方言与DSN
+//
+//	param driver string
+//	return gorm.Dialector
+//	return connInfo
+//	return error
+//	author centonhuang
+//	update 2026-10-18 13:35:08
+func newDialector(driver string) (gorm.Dialector, connInfo, error) {
+	switch driver {
+	case DriverPostgres:
+		return newHostDialector(driver, config.PostgresHost, config.PostgresPort)
+	case DriverMySQL:
+		return newHostDialector(driver, config.MysqlHost, config.MysqlPort)
+	case DriverSQLite:
+		if config.SqlitePath != sqliteMemoryPath {
+			if err := os.MkdirAll(filepath.Dir(config.SqlitePath), 0o755); err != nil {
+				return nil, connInfo{}, err
+			}
+		}
+		return sqlite.Open(sqliteDSN(config.SqlitePath)), connInfo{database: config.SqlitePath}, nil
+	default:
+		return nil, connInfo{}, fmt.Errorf("%w: %q", ErrUnsupportedDriver, driver)
+	}
+}
+
+// newHostDialector 按驱动构造指定主机的方言，主库与只读副本共用账号和库名
+//
+//	param driver string
+//	param host string
+//	param port string
+//	return gorm.Dialector
+//	return connInfo
+//	return error
+//	author centonhuang
+//	update 2026-10-18 13:55:02
+func newHostDialector(driver, host, port string) (gorm.Dialector, connInfo, error) {
+	switch driver {
+	case DriverPostgres:
+		dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=%s",
+			host, config.PostgresUser, config.PostgresPassword,
+			config.PostgresDatabase, port, config.PostgresSSLMode, config.DatabaseTimeZone)
+		return postgres.Open(dsn), connInfo{host, port, config.PostgresDatabase}, nil
+	case DriverMySQL:
+		// multiStatements 允许SQL迁移文件中包含多条语句
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&multiStatements=true&loc=%s",
+			config.MysqlUser, config.MysqlPassword, host, port,
+			config.MysqlDatabase, url.QueryEscape(config.DatabaseTimeZone))
+		return mysql.New(mysql.Config{
+			DSN:               dsn,
+			DefaultStringSize: 256,
+		}), connInfo{host, port, config.MysqlDatabase}, nil
+	default:
+		return nil, connInfo{}, fmt.Errorf("%w: %q has no network host", ErrUnsupportedDriver, driver)
+	}
+}
+
+// configurePool 按驱动设置连接池
+//
+//	param sqlDB *sql.DB
+//	param driver string
+//	author centonhuang
+//	update 2026-10-18 13:35:14
+func configurePool(sqlDB *sql.DB, driver string) {
+	sqlDB.SetMaxIdleConns(config.DatabaseMaxIdleConns)
+	sqlDB.SetMaxOpenConns(config.DatabaseMaxOpenConns)
+	sqlDB.SetConnMaxLifetime(config.DatabaseConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(config.DatabaseConnMaxIdleTime)
+
+	// SQLite 同一时刻只允许一个写连接，多连接并发写会返回 database is locked
+	if driver == DriverSQLite {
+		sqlDB.SetMaxOpenConns(1)
+	}
+}
+
+// sqliteDSN 开启外键约束与忙等待，文件数据库使用WAL提升并发读
+func sqliteDSN(path string) string {
+	pragmas := []string{"_pragma=foreign_keys(1)", "_pragma=busy_timeout(5000)"}
+	if path != sqliteMemoryPath && !strings.Contains(path, "mode=memory") {
+		pragmas = append(pragmas, "_pragma=journal_mode(WAL)")
+	}
+
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + strings.Join(pragmas, "&")
+}
+
+// OpenSQLiteMemory 打开一个独立的SQLite内存数据库，用于单元测试
+//
+//	每次调用得到的数据库互相隔离，连接池内的连接共享同一个内存数据库，关闭所有连接后数据即被释放
+//
+//	return *gorm.DB
+//	return error
+//	author centonhuang
+//	update 2026-10-18 13:35:20
+func OpenSQLiteMemory() (*gorm.DB, error) {
+	path := fmt.Sprintf("file:memdb%d?mode=memory&cache=shared", memoryDBCounter.Add(1))
+	instance, err := openDB(sqlite.Open(sqliteDSN(path)))
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := instance.DB()
+	if err != nil {
+		return nil, err
+	}
+	// 内存数据库在最后一个连接关闭时销毁，保持连接常驻
+	sqlDB.SetMaxOpenConns(1)
+	sqlDB.SetMaxIdleConns(1)
+	sqlDB.SetConnMaxLifetime(0)
+	sqlDB.SetConnMaxIdleTime(0)
+	return instance, nil
+}
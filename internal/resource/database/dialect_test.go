@@ -0,0 +1,50 @@
+package database
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSQLiteDSN(t *testing.T) {
+	cases := map[string]string{
+		":memory:":                        ":memory:?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)",
+		"file:t?mode=memory&cache=shared": "file:t?mode=memory&cache=shared&_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)",
+		"data/app.db":                     "data/app.db?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)",
+	}
+	for path, want := range cases {
+		if got := sqliteDSN(path); got != want {
+			t.Errorf("sqliteDSN(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestNewDialectorUnsupportedDriver(t *testing.T) {
+	if _, _, err := newDialector("oracle"); !errors.Is(err, ErrUnsupportedDriver) {
+		t.Fatalf("want ErrUnsupportedDriver, got %v", err)
+	}
+	if _, _, err := newHostDialector(DriverSQLite, "127.0.0.1", "0"); !errors.Is(err, ErrUnsupportedDriver) {
+		t.Fatalf("sqlite has no network host: want ErrUnsupportedDriver, got %v", err)
+	}
+}
+
+func TestOpenSQLiteMemoryIsolated(t *testing.T) {
+	type item struct {
+		ID uint
+	}
+
+	first, err := OpenSQLiteMemory()
+	if err != nil {
+		t.Fatalf("open first: %v", err)
+	}
+	second, err := OpenSQLiteMemory()
+	if err != nil {
+		t.Fatalf("open second: %v", err)
+	}
+
+	if err := first.AutoMigrate(&item{}); err != nil {
+		t.Fatalf("migrate first: %v", err)
+	}
+	if second.Migrator().HasTable(&item{}) {
+		t.Fatal("memory databases share tables")
+	}
+}
@@ -0,0 +1,185 @@
+// Package migration 版本化数据库迁移
+//
+//	update 2026-10-18 11:02:10
+package migration
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	sqlDir       = "sql"
+	upSuffix     = ".up.sql"
+	downSuffix   = ".down.sql"
+	versionFmt   = "20060102150405"
+	nameSplitter = "_"
+)
+
+//go:embed all:sql
+var sqlFS embed.FS
+
+// Migration 单个迁移
+//
+//	author centonhuang
+//	update 2026-10-18 11:02:16
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// SchemaMigration 迁移历史表模型
+//
+//	author centonhuang
+//	update 2026-10-18 11:02:22
+type SchemaMigration struct {
+	Version   int64     `gorm:"column:version;primaryKey;autoIncrement:false;comment:迁移版本"`
+	Name      string    `gorm:"column:name;not null;comment:迁移名称"`
+	AppliedAt time.Time `gorm:"column:applied_at;not null;comment:执行时间"`
+}
+
+// TableName 表名
+//
+//	receiver SchemaMigration
+//	return string
+//	author centonhuang
+//	update 2026-10-18 11:02:28
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrations 返回Go迁移与内嵌SQL迁移合并后按版本排序的列表
+//
+//	SQL文件可以带方言后缀，如 <version>_<name>.mysql.up.sql，带方言后缀的文件优先于通用文件，
+//	其他方言的文件会被忽略
+//
+//	param dialect string 数据库方言，即 gorm.Dialector.Name()
+//	return []*Migration
+//	return error
+//	author centonhuang
+//	update 2026-10-18 13:31:02
+func Migrations(dialect string) ([]*Migration, error) {
+	migrations := map[int64]*Migration{}
+	for _, m := range goMigrations {
+		if _, ok := migrations[m.Version]; ok {
+			return nil, fmt.Errorf("duplicate migration version %d", m.Version)
+		}
+		migrations[m.Version] = m
+	}
+
+	sqlMigrations, err := loadSQLMigrations(dialect)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range sqlMigrations {
+		if _, ok := migrations[m.Version]; ok {
+			return nil, fmt.Errorf("duplicate migration version %d", m.Version)
+		}
+		migrations[m.Version] = m
+	}
+
+	sorted := make([]*Migration, 0, len(migrations))
+	for _, m := range migrations {
+		sorted = append(sorted, m)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return sorted, nil
+}
+
+func loadSQLMigrations(dialect string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(sqlFS, sqlDir)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := map[int64]*Migration{}
+	// specific 记录 up/down 文件是否已由方言专属文件提供
+	specific := map[string]bool{}
+	for _, entry := range entries {
+		fileName := entry.Name()
+		var isUp bool
+		switch {
+		case strings.HasSuffix(fileName, upSuffix):
+			isUp = true
+		case strings.HasSuffix(fileName, downSuffix):
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(strings.TrimSuffix(fileName, upSuffix), downSuffix)
+		fileDialect := strings.TrimPrefix(path.Ext(base), ".")
+		if fileDialect != "" {
+			if fileDialect != dialect {
+				continue
+			}
+			base = strings.TrimSuffix(base, "."+fileDialect)
+		}
+
+		version, name, err := parseFileName(base)
+		if err != nil {
+			return nil, err
+		}
+
+		slot := fmt.Sprintf("%d/%t", version, isUp)
+		if specific[slot] && fileDialect == "" {
+			continue
+		}
+		specific[slot] = fileDialect != ""
+
+		content, err := fs.ReadFile(sqlFS, path.Join(sqlDir, fileName))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := migrations[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			migrations[version] = m
+		}
+		if isUp {
+			m.Up = execSQL(string(content))
+		} else {
+			m.Down = execSQL(string(content))
+		}
+	}
+
+	result := make([]*Migration, 0, len(migrations))
+	for _, m := range migrations {
+		if m.Up == nil {
+			return nil, fmt.Errorf("migration %d_%s is missing %s file", m.Version, m.Name, upSuffix)
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+func parseFileName(base string) (version int64, name string, err error) {
+	versionStr, name, ok := strings.Cut(base, nameSplitter)
+	if !ok {
+		return 0, "", fmt.Errorf("invalid migration file name %q, expected <version>_<name>", base)
+	}
+	version, err = strconv.ParseInt(versionStr, 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid migration version in %q: %w", base, err)
+	}
+	return
+}
+
+func execSQL(content string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		if strings.TrimSpace(content) == "" {
+			return nil
+		}
+		return tx.Exec(content).Error
+	}
+}
@@ -0,0 +1,390 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// advisoryLockKey 迁移使用的Postgres咨询锁ID，防止多个实例并发迁移
+	advisoryLockKey int64 = 20261018
+
+	// namedLockKey 迁移使用的MySQL命名锁
+	namedLockKey = "schema_migrations"
+)
+
+var migrationNameRegexp = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Status 迁移状态
+//
+//	author centonhuang
+//	update 2026-10-18 11:15:02
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator 迁移执行器
+//
+//	author centonhuang
+//	update 2026-10-18 11:15:08
+type Migrator struct {
+	db         *gorm.DB
+	migrations []*Migration
+}
+
+// NewMigrator 创建迁移执行器
+//
+//	param db *gorm.DB
+//	return *Migrator
+//	return error
+//	author centonhuang
+//	update 2026-10-18 11:15:14
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := Migrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up 执行所有未执行的迁移
+//
+//	receiver m *Migrator
+//	param ctx context.Context
+//	return applied []*Migration
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 11:15:20
+func (m *Migrator) Up(ctx context.Context) (applied []*Migration, err error) {
+	err = m.withLock(ctx, func(db *gorm.DB) error {
+		appliedVersions, err := m.appliedVersions(db)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := appliedVersions[migration.Version]; ok {
+				continue
+			}
+
+			logger.Logger().Info("[Migration] applying", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+			if err := db.Transaction(func(tx *gorm.DB) error {
+				if err := migration.Up(tx); err != nil {
+					return err
+				}
+				return tx.Create(&SchemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now().UTC()}).Error
+			}); err != nil {
+				return fmt.Errorf("apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return
+}
+
+// Down 回滚最近执行的 steps 个迁移
+//
+//	receiver m *Migrator
+//	param ctx context.Context
+//	param steps int
+//	return reverted []*Migration
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 11:15:26
+func (m *Migrator) Down(ctx context.Context, steps int) (reverted []*Migration, err error) {
+	err = m.withLock(ctx, func(db *gorm.DB) error {
+		var records []SchemaMigration
+		if err := db.Order("version DESC").Limit(steps).Find(&records).Error; err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			migration := m.find(record.Version)
+			if migration == nil {
+				return fmt.Errorf("migration %d_%s is applied but unknown to this binary", record.Version, record.Name)
+			}
+			if migration.Down == nil {
+				return fmt.Errorf("migration %d_%s is irreversible", migration.Version, migration.Name)
+			}
+
+			logger.Logger().Info("[Migration] reverting", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+			if err := db.Transaction(func(tx *gorm.DB) error {
+				if err := migration.Down(tx); err != nil {
+					return err
+				}
+				return tx.Delete(&SchemaMigration{}, "version = ?", migration.Version).Error
+			}); err != nil {
+				return fmt.Errorf("revert migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return
+}
+
+// Baseline 将 version 及之前的迁移标记为已执行而不真正执行，用于接入已有数据库，version 为0表示全部
+//
+//	receiver m *Migrator
+//	param ctx context.Context
+//	param version int64
+//	return marked []*Migration
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 11:15:32
+func (m *Migrator) Baseline(ctx context.Context, version int64) (marked []*Migration, err error) {
+	err = m.withLock(ctx, func(db *gorm.DB) error {
+		appliedVersions, err := m.appliedVersions(db)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if version != 0 && migration.Version > version {
+				break
+			}
+			if _, ok := appliedVersions[migration.Version]; ok {
+				continue
+			}
+			if err := db.Create(&SchemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now().UTC()}).Error; err != nil {
+				return err
+			}
+			marked = append(marked, migration)
+		}
+		return nil
+	})
+	return
+}
+
+// Status 查询所有迁移的执行状态
+//
+//	receiver m *Migrator
+//	param ctx context.Context
+//	return statuses []Status
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 11:15:38
+func (m *Migrator) Status(ctx context.Context) (statuses []Status, err error) {
+	db := m.db.WithContext(ctx)
+	if err = m.ensureTable(db); err != nil {
+		return
+	}
+
+	var records []SchemaMigration
+	if err = db.Order("version").Find(&records).Error; err != nil {
+		return
+	}
+	recordMapping := make(map[int64]SchemaMigration, len(records))
+	for _, record := range records {
+		recordMapping[record.Version] = record
+	}
+
+	for _, migration := range m.migrations {
+		record, ok := recordMapping[migration.Version]
+		statuses = append(statuses, Status{Version: migration.Version, Name: migration.Name, Applied: ok, AppliedAt: record.AppliedAt})
+		delete(recordMapping, migration.Version)
+	}
+	for _, record := range recordMapping {
+		statuses = append(statuses, Status{Version: record.Version, Name: record.Name, Applied: true, AppliedAt: record.AppliedAt})
+	}
+	return
+}
+
+// Check 检查迁移与模型是否漂移，返回发现的问题列表
+//
+//	receiver m *Migrator
+//	param ctx context.Context
+//	param models ...interface{}
+//	return problems []string
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 11:15:44
+func (m *Migrator) Check(ctx context.Context, models ...interface{}) (problems []string, err error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return
+	}
+	for _, status := range statuses {
+		switch {
+		case !status.Applied:
+			problems = append(problems, fmt.Sprintf("migration %d_%s is pending", status.Version, status.Name))
+		case m.find(status.Version) == nil:
+			problems = append(problems, fmt.Sprintf("migration %d_%s is applied but unknown to this binary", status.Version, status.Name))
+		}
+	}
+
+	db := m.db.WithContext(ctx)
+	migrator := db.Migrator()
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err = stmt.Parse(model); err != nil {
+			return
+		}
+		table := stmt.Schema.Table
+
+		if !migrator.HasTable(model) {
+			problems = append(problems, fmt.Sprintf("table %s is defined in model but missing in database", table))
+			continue
+		}
+
+		columnTypes, err := migrator.ColumnTypes(model)
+		if err != nil {
+			return nil, err
+		}
+		dbColumns := make(map[string]struct{}, len(columnTypes))
+		for _, columnType := range columnTypes {
+			dbColumns[columnType.Name()] = struct{}{}
+		}
+
+		modelColumns := map[string]struct{}{}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" || field.IgnoreMigration {
+				continue
+			}
+			modelColumns[field.DBName] = struct{}{}
+			if _, ok := dbColumns[field.DBName]; !ok {
+				problems = append(problems, fmt.Sprintf("column %s.%s is defined in model but missing in database", table, field.DBName))
+			}
+		}
+		for column := range dbColumns {
+			if _, ok := modelColumns[column]; !ok {
+				problems = append(problems, fmt.Sprintf("column %s.%s exists in database but not in model", table, column))
+			}
+		}
+	}
+	return
+}
+
+// Create 在 dir 下生成一对空的SQL迁移文件，dialect 非空时生成方言专属文件
+//
+//	param dir string
+//	param name string
+//	param dialect string
+//	return upPath string
+//	return downPath string
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 13:31:20
+func Create(dir, name, dialect string) (upPath, downPath string, err error) {
+	name = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "-", "_"))
+	if !migrationNameRegexp.MatchString(name) {
+		return "", "", fmt.Errorf("invalid migration name %q, only [a-z0-9_] is allowed", name)
+	}
+
+	base := fmt.Sprintf("%s_%s", time.Now().UTC().Format(versionFmt), name)
+	if dialect != "" {
+		base += "." + dialect
+	}
+	upPath, downPath = filepath.Join(dir, base+upSuffix), filepath.Join(dir, base+downSuffix)
+
+	if err = os.WriteFile(upPath, []byte(fmt.Sprintf("-- %s up\n", name)), 0o644); err != nil {
+		return
+	}
+	err = os.WriteFile(downPath, []byte(fmt.Sprintf("-- %s down\n", name)), 0o644)
+	return
+}
+
+// Reset 删除模型表与迁移记录后重新执行全部迁移，仅用于本地开发
+//
+//	receiver m *Migrator
+//	param ctx context.Context
+//	param models ...interface{}
+//	return applied []*Migration
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 13:10:41
+func (m *Migrator) Reset(ctx context.Context, models ...interface{}) (applied []*Migration, err error) {
+	err = m.withLock(ctx, func(db *gorm.DB) error {
+		tables := append(append([]interface{}{}, models...), &SchemaMigration{})
+		logger.Logger().Warn("[Migration] dropping tables", zap.Int("count", len(tables)))
+		return db.Migrator().DropTable(tables...)
+	})
+	if err != nil {
+		return
+	}
+	return m.Up(ctx)
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) ensureTable(db *gorm.DB) error {
+	return db.AutoMigrate(&SchemaMigration{})
+}
+
+func (m *Migrator) appliedVersions(db *gorm.DB) (map[int64]struct{}, error) {
+	var versions []int64
+	if err := db.Model(&SchemaMigration{}).Pluck("version", &versions).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[int64]struct{}, len(versions))
+	for _, version := range versions {
+		result[version] = struct{}{}
+	}
+	return result, nil
+}
+
+// withLock 持有数据库锁执行 fn，Postgres 使用咨询锁，MySQL 使用命名锁，SQLite 为单文件数据库直接执行
+func (m *Migrator) withLock(ctx context.Context, fn func(db *gorm.DB) error) error {
+	db := m.db.WithContext(ctx)
+	if err := m.ensureTable(db); err != nil {
+		return err
+	}
+
+	var lockSQL, unlockSQL string
+	var lockKey interface{}
+	switch db.Dialector.Name() {
+	case "postgres":
+		lockSQL, unlockSQL, lockKey = "SELECT 1 FROM pg_advisory_lock($1)", "SELECT pg_advisory_unlock($1)", advisoryLockKey
+	case "mysql":
+		lockSQL, unlockSQL, lockKey = "SELECT GET_LOCK(?, -1)", "SELECT RELEASE_LOCK(?)", namedLockKey
+	default:
+		return fn(db)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	logger.Logger().Info("[Migration] waiting for migration lock", zap.Any("lockKey", lockKey))
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, lockSQL, lockKey).Scan(&locked); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	if locked.Int64 != 1 {
+		return fmt.Errorf("acquire migration lock: lock %v not granted", lockKey)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), unlockSQL, lockKey); err != nil {
+			logger.Logger().Error("[Migration] failed to release migration lock", zap.Error(err))
+		}
+	}()
+
+	return fn(db)
+}
@@ -0,0 +1,57 @@
+package migration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/dbtest"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/migration"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
+)
+
+func TestSQLiteMigrationsMatchModels(t *testing.T) {
+	ctx := context.Background()
+	migrator, err := migration.NewMigrator(dbtest.New(t))
+	if err != nil {
+		t.Fatalf("create migrator: %v", err)
+	}
+
+	problems, err := migrator.Check(ctx, model.Models...)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if len(problems) > 0 {
+		t.Fatalf("sqlite schema drifts from models: %v", problems)
+	}
+}
+
+func TestSQLiteMigrationsDownUp(t *testing.T) {
+	ctx := context.Background()
+	migrator, err := migration.NewMigrator(dbtest.New(t))
+	if err != nil {
+		t.Fatalf("create migrator: %v", err)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	reverted, err := migrator.Down(ctx, len(statuses))
+	if err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	if len(reverted) != len(statuses) {
+		t.Fatalf("down reverted %d of %d migrations", len(reverted), len(statuses))
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("up again: %v", err)
+	}
+	if len(applied) != len(statuses) {
+		t.Fatalf("up applied %d of %d migrations", len(applied), len(statuses))
+	}
+	if problems, err := migrator.Check(ctx, model.Models...); err != nil || len(problems) > 0 {
+		t.Fatalf("check after down/up: problems=%v err=%v", problems, err)
+	}
+}
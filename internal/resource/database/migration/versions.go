@@ -0,0 +1,102 @@
+package migration
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// goMigrations Go迁移列表，结构体为迁移时刻的快照，不要直接引用 model 包中会继续演进的模型
+//
+//	update 2026-10-18 19:30:02
+var goMigrations = []*Migration{
+	{
+		Version: 20261018110000,
+		Name:    "create_users",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&userV20261018110000{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&userV20261018110000{})
+		},
+	},
+	{
+		Version: 20261018142000,
+		Name:    "add_users_version",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().AddColumn(&userV20261018142000{}, "Version")
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&userV20261018142000{}, "Version")
+		},
+	},
+	{
+		Version: 20261018165600,
+		Name:    "add_users_locale",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().AddColumn(&userV20261018165600{}, "Locale")
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&userV20261018165600{}, "Locale")
+		},
+	},
+	{
+		Version: 20261018193000,
+		Name:    "add_users_tenant_id",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().AddColumn(&userV20261018193000{}, "TenantID"); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateIndex(&userV20261018193000{}, "TenantID")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropIndex(&userV20261018193000{}, "TenantID"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&userV20261018193000{}, "TenantID")
+		},
+	},
+}
+
+type userV20261018110000 struct {
+	ID           uint      `gorm:"column:id;primary_key;auto_increment;comment:ID"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime;comment:创建时间"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;autoUpdateTime;comment:更新时间"`
+	DeletedAt    int64     `gorm:"column:deleted_at;default:0;comment:删除时间，默认为0"`
+	Name         string    `gorm:"column:name;unique;not null;comment:用户名"`
+	Email        string    `gorm:"column:email;unique;not null;comment:邮箱"`
+	Avatar       string    `gorm:"column:avatar;not null;comment:头像"`
+	Permission   string    `gorm:"column:permission;not null;default:'reader';comment:权限"`
+	LastLogin    time.Time `gorm:"column:last_login;comment:最后登录时间"`
+	GithubBindID string    `gorm:"unique;comment:Github绑定ID"`
+	QQBindID     string    `gorm:"unique;comment:QQ绑定ID"`
+	GoogleBindID string    `gorm:"unique;comment:Google绑定ID"`
+}
+
+func (userV20261018110000) TableName() string {
+	return "users"
+}
+
+type userV20261018142000 struct {
+	Version uint `gorm:"column:version;not null;default:1;comment:乐观锁版本号"`
+}
+
+func (userV20261018142000) TableName() string {
+	return "users"
+}
+
+type userV20261018165600 struct {
+	Locale string `gorm:"column:locale;not null;default:'';comment:界面语言，为空时按请求协商"`
+}
+
+func (userV20261018165600) TableName() string {
+	return "users"
+}
+
+type userV20261018193000 struct {
+	TenantID uint `gorm:"column:tenant_id;index;not null;default:0;comment:所属租户ID"`
+}
+
+func (userV20261018193000) TableName() string {
+	return "users"
+}
@@ -0,0 +1,53 @@
+package model
+
+// TenantScoped 租户隔离模型接口，嵌入 TenantModel 的模型自动实现
+//
+//	author centonhuang
+//	update 2026-10-18 10:05:21
+type TenantScoped interface {
+	GetTenantID() uint
+	SetTenantID(tenantID uint)
+}
+
+// TenantModel 租户模型，嵌入后该模型经由DAO的读写都会按上下文中的租户隔离
+//
+//	接入方式：在业务模型中嵌入 TenantModel，并新增迁移为对应的表添加带索引的 tenant_id 列；
+//	请求中的租户由 middleware.JwtMiddleware 鉴权后取自已鉴权用户的 User.TenantID，不信任客户端传入的租户；
+//	定时任务、管理后台等需要跨租户访问的场景使用 database.WithTenant 或 database.WithoutTenantScope 显式指定
+//
+//	author centonhuang
+//	update 2026-10-18 20:16:14
+type TenantModel struct {
+	TenantID uint `json:"tenant_id" gorm:"column:tenant_id;index;not null;default:0;comment:租户ID"`
+}
+
+// GetTenantID 获取租户ID
+//
+//	receiver m *TenantModel
+//	return uint
+//	author centonhuang
+//	update 2026-10-18 10:05:33
+func (m *TenantModel) GetTenantID() uint {
+	return m.TenantID
+}
+
+// SetTenantID 设置租户ID
+//
+//	receiver m *TenantModel
+//	param tenantID uint
+//	author centonhuang
+//	update 2026-10-18 10:05:39
+func (m *TenantModel) SetTenantID(tenantID uint) {
+	m.TenantID = tenantID
+}
+
+// IsTenantScoped 判断模型是否开启了租户隔离
+//
+//	param m interface{}
+//	return bool
+//	author centonhuang
+//	update 2026-10-18 10:05:45
+func IsTenantScoped(m interface{}) bool {
+	_, ok := m.(TenantScoped)
+	return ok
+}
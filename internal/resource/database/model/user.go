@@ -60,15 +60,21 @@ var (
 
 // User 用户数据库模型
 //
+//	用户是登录主体，用户表本身不做租户隔离；TenantID 为用户所属的租户，
+//	鉴权后写入请求上下文，决定该请求经由DAO访问哪个租户的数据
+//
 //	author centonhuang
-//	update 2024-06-22 09:36:22
+//	update 2026-10-18 19:30:08
 type User struct {
 	BaseModel
+	VersionModel
+	TenantID     uint       `json:"tenant_id" gorm:"column:tenant_id;index;not null;default:0;comment:所属租户ID，0表示未分配租户"`
 	Name         string     `json:"name" gorm:"column:name;unique;not null;comment:用户名"`
 	Email        string     `json:"email" gorm:"column:email;unique;not null;comment:邮箱"`
 	Avatar       string     `json:"avatar" gorm:"column:avatar;not null;comment:头像"`
 	Permission   Permission `json:"permission" gorm:"column:permission;not null;default:'reader';comment:权限"`
 	LastLogin    time.Time  `json:"last_login" gorm:"column:last_login;comment:最后登录时间"`
+	Locale       string     `json:"locale" gorm:"column:locale;not null;default:'';comment:界面语言，为空时按请求协商"`
 	GithubBindID string     `json:"-" gorm:"unique;comment:Github绑定ID"`
 	QQBindID     string     `json:"-" gorm:"unique;comment:QQ绑定ID"`
 	GoogleBindID string     `json:"-" gorm:"unique;comment:Google绑定ID"`
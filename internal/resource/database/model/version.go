@@ -0,0 +1,49 @@
+package model
+
+// Versioned 乐观锁模型接口，嵌入 VersionModel 的模型自动实现
+//
+//	author centonhuang
+//	update 2026-10-18 14:20:02
+type Versioned interface {
+	GetVersion() uint
+	SetVersion(version uint)
+}
+
+// VersionModel 乐观锁版本号，嵌入后该模型经由DAO的每次更新都会递增版本号，可配合 UpdateWithVersion 做条件更新
+//
+//	author centonhuang
+//	update 2026-10-18 14:20:08
+type VersionModel struct {
+	Version uint `json:"version" gorm:"column:version;not null;default:1;comment:乐观锁版本号"`
+}
+
+// GetVersion 获取版本号
+//
+//	receiver m *VersionModel
+//	return uint
+//	author centonhuang
+//	update 2026-10-18 14:20:14
+func (m *VersionModel) GetVersion() uint {
+	return m.Version
+}
+
+// SetVersion 设置版本号
+//
+//	receiver m *VersionModel
+//	param version uint
+//	author centonhuang
+//	update 2026-10-18 14:20:20
+func (m *VersionModel) SetVersion(version uint) {
+	m.Version = version
+}
+
+// IsVersioned 判断模型是否开启了乐观锁
+//
+//	param m interface{}
+//	return bool
+//	author centonhuang
+//	update 2026-10-18 14:20:26
+func IsVersioned(m interface{}) bool {
+	_, ok := m.(Versioned)
+	return ok
+}
@@ -0,0 +1,274 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/constant"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"go.uber.org/zap"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+const replicaCheckTimeout = 3 * time.Second
+
+// WithPrimary 强制读请求走主库，用于写后立即读的场景(read-your-writes)
+//
+//	param ctx context.Context
+//	return context.Context
+//	author centonhuang
+//	update 2026-10-18 13:58:02
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, constant.CtxKeyForcePrimary, true)
+}
+
+// IsPrimaryForced 判断上下文是否强制走主库
+//
+//	param ctx context.Context
+//	return bool
+//	author centonhuang
+//	update 2026-10-18 13:58:08
+func IsPrimaryForced(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	forced, _ := ctx.Value(constant.CtxKeyForcePrimary).(bool)
+	return forced
+}
+
+// replica 只读副本及其最近一次健康检查的结果
+type replica struct {
+	addr    string
+	pool    *sql.DB
+	healthy atomic.Bool
+	lag     atomic.Int64
+}
+
+func (r *replica) available(maxLag time.Duration) bool {
+	return r.healthy.Load() && time.Duration(r.lag.Load()) <= maxLag
+}
+
+// replicaPolicy 在健康且复制延迟可接受的副本间轮询，全部不可用时回退主库
+//
+//	author centonhuang
+//	update 2026-10-18 13:58:14
+type replicaPolicy struct {
+	driver   string
+	primary  gorm.ConnPool
+	replicas map[gorm.ConnPool]*replica
+	maxLag   time.Duration
+	next     atomic.Uint64
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// replicas 已注册的只读副本，关闭数据库时停止健康检查并关闭副本连接池
+var replicas *replicaPolicy
+
+// Resolve 选择读连接池
+//
+//	receiver p *replicaPolicy
+//	param pools []gorm.ConnPool
+//	return gorm.ConnPool
+//	author centonhuang
+//	update 2026-10-18 13:58:20
+func (p *replicaPolicy) Resolve(pools []gorm.ConnPool) gorm.ConnPool {
+	n := uint64(len(pools))
+	start := p.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		pool := pools[(start+i)%n]
+		if r, ok := p.replicas[pool]; ok && r.available(p.maxLag) {
+			return pool
+		}
+	}
+	return p.primary
+}
+
+// registerReplicas 为 instance 注册只读副本，读请求经 replicaPolicy 路由，写请求与事务始终走主库
+//
+//	param instance *gorm.DB
+//	param driver string
+//	param addrs []string
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:41:02
+func registerReplicas(instance *gorm.DB, driver string, addrs []string) error {
+	primary, err := instance.DB()
+	if err != nil {
+		return err
+	}
+
+	policy := &replicaPolicy{
+		driver:   driver,
+		primary:  primary,
+		replicas: make(map[gorm.ConnPool]*replica, len(addrs)),
+		maxLag:   config.DatabaseReplicaMaxLag,
+	}
+
+	// 主库也作为候选注册进去：dbresolver 只有一个副本时会跳过 Policy，且回退主库时需要返回候选之一
+	dialectors := []gorm.Dialector{wrapConn(driver, primary)}
+	for _, addr := range addrs {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return fmt.Errorf("invalid replica address %q: %w", addr, err)
+		}
+
+		dialector, _, err := newHostDialector(driver, host, port)
+		if err != nil {
+			return err
+		}
+		replicaDB, err := gorm.Open(dialector, &gorm.Config{DisableAutomaticPing: true})
+		if err != nil {
+			return fmt.Errorf("open replica %s: %w", addr, err)
+		}
+		pool, err := replicaDB.DB()
+		if err != nil {
+			return err
+		}
+		configurePool(pool, driver)
+
+		policy.replicas[pool] = &replica{addr: addr, pool: pool}
+		dialectors = append(dialectors, wrapConn(driver, pool))
+	}
+
+	// 启动前先检查一次，避免首批读请求落到尚未确认状态的副本
+	policy.check()
+	ctx, cancel := context.WithCancel(context.Background())
+	policy.cancel, policy.done = cancel, make(chan struct{})
+	go policy.monitor(ctx, config.DatabaseReplicaCheckInterval)
+	replicas = policy
+
+	return instance.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		Policy:   policy,
+	}))
+}
+
+// wrapConn 复用已打开的连接池构造方言，dbresolver 据此得到与 policy 中相同的连接池
+func wrapConn(driver string, pool *sql.DB) gorm.Dialector {
+	if driver == DriverMySQL {
+		return mysql.New(mysql.Config{Conn: pool, SkipInitializeWithVersion: true})
+	}
+	return postgres.New(postgres.Config{Conn: pool})
+}
+
+func (p *replicaPolicy) monitor(ctx context.Context, interval time.Duration) {
+	defer close(p.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.check()
+		}
+	}
+}
+
+// close 停止健康检查并关闭副本连接池
+func (p *replicaPolicy) close() error {
+	p.cancel()
+	<-p.done
+
+	var errs []error
+	for _, r := range p.replicas {
+		if err := r.pool.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close replica %s: %w", r.addr, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *replicaPolicy) check() {
+	for _, r := range p.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), replicaCheckTimeout)
+		lag, err := p.replicationLag(ctx, r.pool)
+		cancel()
+
+		healthy := err == nil
+		if was := r.healthy.Swap(healthy); was != healthy {
+			if healthy {
+				logger.Logger().Info("[Database] Replica is healthy", zap.String("replica", r.addr))
+			} else {
+				logger.Logger().Warn("[Database] Replica is unhealthy, reads fall back", zap.String("replica", r.addr), zap.Error(err))
+			}
+		}
+		if !healthy {
+			continue
+		}
+
+		if previous := time.Duration(r.lag.Swap(int64(lag))); lag > p.maxLag && previous <= p.maxLag {
+			logger.Logger().Warn("[Database] Replica lag exceeds limit",
+				zap.String("replica", r.addr), zap.Duration("lag", lag), zap.Duration("maxLag", p.maxLag))
+		}
+	}
+}
+
+// replicationLag 查询副本的复制延迟，查询失败视为副本不健康
+func (p *replicaPolicy) replicationLag(ctx context.Context, pool *sql.DB) (time.Duration, error) {
+	switch p.driver {
+	case DriverPostgres:
+		// 主库空闲时 replay 时间戳不会前进，WAL 已全部回放时视为没有延迟
+		var seconds float64
+		err := pool.QueryRowContext(ctx, `SELECT CASE
+			WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+			ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+		END`).Scan(&seconds)
+		return time.Duration(seconds * float64(time.Second)), err
+	case DriverMySQL:
+		return mysqlReplicationLag(ctx, pool)
+	default:
+		return 0, pool.PingContext(ctx)
+	}
+}
+
+func mysqlReplicationLag(ctx context.Context, pool *sql.DB) (time.Duration, error) {
+	rows, err := pool.QueryContext(ctx, "SHOW REPLICA STATUS")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	if !rows.Next() {
+		// 不是副本，没有复制延迟
+		return 0, rows.Err()
+	}
+
+	values := make([]sql.RawBytes, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return 0, err
+	}
+
+	for i, column := range columns {
+		if column != "Seconds_Behind_Source" {
+			continue
+		}
+		if values[i] == nil {
+			return 0, fmt.Errorf("replication is not running")
+		}
+		seconds, err := strconv.ParseInt(string(values[i]), 10, 64)
+		return time.Duration(seconds) * time.Second, err
+	}
+	return 0, fmt.Errorf("column Seconds_Behind_Source not found")
+}
@@ -0,0 +1,46 @@
+package seed
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
+)
+
+var fakePermissions = []model.Permission{
+	model.PermissionReader,
+	model.PermissionCreator,
+	model.PermissionAdmin,
+}
+
+// FakeUsers 生成确定性的假用户，相同的 seed 与 n 总是得到相同的数据，权限在所有 Permission 间轮换，并绑定全部第三方平台
+//
+//	param seed int64
+//	param n int
+//	return []*model.User
+//	author centonhuang
+//	update 2026-10-18 13:05:12
+func FakeUsers(seed int64, n int) []*model.User {
+	r := rand.New(rand.NewSource(seed))
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	users := make([]*model.User, 0, n)
+	for i := 1; i <= n; i++ {
+		name := fmt.Sprintf("seeduser%03d", i)
+		user := &model.User{
+			Name:       name,
+			Email:      fmt.Sprintf("%s@example.com", name),
+			Avatar:     fmt.Sprintf("https://api.dicebear.com/9.x/identicon/svg?seed=%s", name),
+			Permission: fakePermissions[(i-1)%len(fakePermissions)],
+			LastLogin:  base.Add(time.Duration(r.Int63n(int64(365 * 24 * time.Hour)))),
+		}
+
+		// 三个第三方绑定ID都带唯一约束，空串也会冲突，因此每个假用户都绑定全部平台
+		user.GithubBindID = fmt.Sprintf("%d", 10000000*i+r.Intn(10000000))
+		user.GoogleBindID = fmt.Sprintf("%d", 100000000000*int64(i)+r.Int63n(100000000000))
+		user.QQBindID = fmt.Sprintf("%08X%024X", i, r.Uint64())
+		users = append(users, user)
+	}
+	return users
+}
@@ -0,0 +1,271 @@
+// Package seed 数据库种子数据与夹具加载
+//
+//	update 2026-10-18 13:01:02
+package seed
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// safeEnvs 允许直接写入种子数据与重置数据库的运行环境，其余环境需要显式确认
+//
+//	update 2026-10-18 19:40:02
+var safeEnvs = []string{"development", "test", "local"}
+
+// ErrUnsafeEnv 当前环境不在允许列表中且未确认
+//
+//	update 2026-10-18 19:40:08
+var ErrUnsafeEnv = errors.New("seeding is only allowed in development/test/local environment, use --force to confirm")
+
+// ErrNotConfirmed 交互确认输入的环境名称不一致
+//
+//	update 2026-10-18 19:40:14
+var ErrNotConfirmed = errors.New("confirmation does not match APP_ENV, aborted")
+
+// ErrEnvNotSet 未设置 APP_ENV，无法判断当前环境
+//
+//	update 2026-10-18 20:10:20
+var ErrEnvNotSet = errors.New("APP_ENV is not set, refusing to seed or reset the database")
+
+type confirmedCtxKey struct{}
+
+// Fixture 夹具文件中的一组数据
+//
+//	author centonhuang
+//	update 2026-10-18 13:01:14
+type Fixture struct {
+	Table string                   `yaml:"table"`
+	Key   []string                 `yaml:"key"`
+	Rows  []map[string]interface{} `yaml:"rows"`
+}
+
+// IsSafeEnv 当前环境是否在允许列表中，未设置 APP_ENV 时视为不安全
+//
+//	return bool
+//	author centonhuang
+//	update 2026-10-18 20:10:08
+func IsSafeEnv() bool {
+	if strings.TrimSpace(config.AppEnv) == "" {
+		return false
+	}
+	for _, env := range safeEnvs {
+		if strings.EqualFold(config.AppEnv, env) {
+			return true
+		}
+	}
+	return false
+}
+
+// Confirm 确认在当前环境执行写入种子数据、重置数据库等危险操作
+//
+//	当前环境在允许列表中时直接通过；否则需要指定 force，并在 in 中输入 APP_ENV 的值确认，确认后的上下文可通过 EnsureSafeEnv 检查。
+//	未设置 APP_ENV 时无法确认，直接返回错误
+//
+//	param ctx context.Context
+//	param force bool
+//	param in io.Reader
+//	param out io.Writer
+//	return context.Context
+//	return error
+//	author centonhuang
+//	update 2026-10-18 20:10:14
+func Confirm(ctx context.Context, force bool, in io.Reader, out io.Writer) (context.Context, error) {
+	if IsSafeEnv() {
+		return ctx, nil
+	}
+	if strings.TrimSpace(config.AppEnv) == "" {
+		return ctx, ErrEnvNotSet
+	}
+	if !force {
+		return ctx, fmt.Errorf("%w: APP_ENV=%s", ErrUnsafeEnv, config.AppEnv)
+	}
+
+	fmt.Fprintf(out, "APP_ENV=%s is not in the allowlist (%s), this operation will overwrite data.\nType the value of APP_ENV to continue: ",
+		config.AppEnv, strings.Join(safeEnvs, "/"))
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return ctx, err
+	}
+	if strings.TrimSpace(answer) != config.AppEnv {
+		return ctx, ErrNotConfirmed
+	}
+	return context.WithValue(ctx, confirmedCtxKey{}, config.AppEnv), nil
+}
+
+// EnsureSafeEnv 检查当前环境在允许列表中，或上下文已通过 Confirm 确认
+//
+//	param ctx context.Context
+//	return error
+//	author centonhuang
+//	update 2026-10-18 20:10:26
+func EnsureSafeEnv(ctx context.Context) error {
+	if IsSafeEnv() {
+		return nil
+	}
+	if env, ok := ctx.Value(confirmedCtxKey{}).(string); ok && env != "" && env == config.AppEnv {
+		return nil
+	}
+	if strings.TrimSpace(config.AppEnv) == "" {
+		return ErrEnvNotSet
+	}
+	return fmt.Errorf("%w: APP_ENV=%s", ErrUnsafeEnv, config.AppEnv)
+}
+
+// LoadFixtureFile 读取夹具文件，支持YAML与JSON，文件可以是单个 Fixture 或 Fixture 列表
+//
+//	param filePath string
+//	return fixtures []Fixture
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 13:01:26
+func LoadFixtureFile(filePath string) (fixtures []Fixture, err error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return
+	}
+
+	if err = yaml.Unmarshal(content, &fixtures); err == nil {
+		return
+	}
+
+	var fixture Fixture
+	if err = yaml.Unmarshal(content, &fixture); err != nil {
+		return nil, fmt.Errorf("parse fixture file %s: %w", filePath, err)
+	}
+	return []Fixture{fixture}, nil
+}
+
+// ApplyFixtures 将夹具映射到 model.Models 中的模型并幂等写入
+//
+//	param ctx context.Context
+//	param fixtures []Fixture
+//	return affected int64
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 19:40:38
+func ApplyFixtures(ctx context.Context, fixtures []Fixture) (affected int64, err error) {
+	if err = EnsureSafeEnv(ctx); err != nil {
+		return
+	}
+
+	db := database.GetDBInstance(database.WithoutTenantScope(ctx))
+	schemas, err := modelSchemas(db)
+	if err != nil {
+		return
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, fixture := range fixtures {
+			modelSchema, ok := schemas[fixture.Table]
+			if !ok {
+				return fmt.Errorf("table %s is not defined in model.Models", fixture.Table)
+			}
+
+			key := fixture.Key
+			if len(key) == 0 {
+				key = []string{modelSchema.PrioritizedPrimaryField.DBName}
+			}
+
+			for i, row := range fixture.Rows {
+				record := reflect.New(modelSchema.ModelType)
+				for column, value := range row {
+					field := lookupField(modelSchema, column)
+					if field == nil {
+						return fmt.Errorf("table %s row %d: unknown column %s", fixture.Table, i, column)
+					}
+					if err := field.Set(ctx, record.Elem(), value); err != nil {
+						return fmt.Errorf("table %s row %d: %w", fixture.Table, i, err)
+					}
+				}
+
+				n, err := upsert(tx, record.Interface(), key)
+				if err != nil {
+					return fmt.Errorf("table %s row %d: %w", fixture.Table, i, err)
+				}
+				affected += n
+			}
+
+			logger.WithCtx(ctx).Info("[Seed] fixture applied", zap.String("table", fixture.Table), zap.Int("rows", len(fixture.Rows)))
+		}
+		return nil
+	})
+	return
+}
+
+// SeedUsers 写入生成的用户，按邮箱幂等
+//
+//	param ctx context.Context
+//	param users []*model.User
+//	return affected int64
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 19:40:44
+func SeedUsers(ctx context.Context, users []*model.User) (affected int64, err error) {
+	if err = EnsureSafeEnv(ctx); err != nil {
+		return
+	}
+
+	db := database.GetDBInstance(database.WithoutTenantScope(ctx))
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, user := range users {
+			n, err := upsert(tx, user, []string{"email"})
+			if err != nil {
+				return fmt.Errorf("seed user %s: %w", user.Email, err)
+			}
+			affected += n
+		}
+		return nil
+	})
+	return
+}
+
+func upsert(tx *gorm.DB, record interface{}, key []string) (int64, error) {
+	columns := make([]clause.Column, 0, len(key))
+	for _, k := range key {
+		columns = append(columns, clause.Column{Name: k})
+	}
+
+	result := tx.Clauses(clause.OnConflict{Columns: columns, UpdateAll: true}).Create(record)
+	return result.RowsAffected, result.Error
+}
+
+func modelSchemas(db *gorm.DB) (map[string]*schema.Schema, error) {
+	schemas := make(map[string]*schema.Schema, len(model.Models))
+	for _, m := range model.Models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(m); err != nil {
+			return nil, err
+		}
+		schemas[stmt.Schema.Table] = stmt.Schema
+	}
+	return schemas, nil
+}
+
+func lookupField(modelSchema *schema.Schema, column string) *schema.Field {
+	if field := modelSchema.LookUpField(column); field != nil {
+		return field
+	}
+	for _, field := range modelSchema.Fields {
+		if strings.EqualFold(field.Tag.Get("json"), column) {
+			return field
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/dbtest"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
+	"gorm.io/gorm"
+)
+
+func setAppEnv(t *testing.T, env string) {
+	t.Helper()
+
+	previous := config.AppEnv
+	config.AppEnv = env
+	t.Cleanup(func() { config.AppEnv = previous })
+}
+
+func TestConfirmSafeEnv(t *testing.T) {
+	for _, env := range []string{"development", "test", "Local"} {
+		setAppEnv(t, env)
+		if _, err := Confirm(context.Background(), false, strings.NewReader(""), io.Discard); err != nil {
+			t.Fatalf("APP_ENV=%s: want allowed, got %v", env, err)
+		}
+		if err := EnsureSafeEnv(context.Background()); err != nil {
+			t.Fatalf("APP_ENV=%s: want safe, got %v", env, err)
+		}
+	}
+}
+
+func TestConfirmUnsafeEnv(t *testing.T) {
+	setAppEnv(t, "staging")
+
+	if err := EnsureSafeEnv(context.Background()); !errors.Is(err, ErrUnsafeEnv) {
+		t.Fatalf("unconfirmed context: want ErrUnsafeEnv, got %v", err)
+	}
+	if _, err := Confirm(context.Background(), false, strings.NewReader("staging\n"), io.Discard); !errors.Is(err, ErrUnsafeEnv) {
+		t.Fatalf("without force: want ErrUnsafeEnv, got %v", err)
+	}
+	for _, answer := range []string{"", "y\n", "production\n"} {
+		if _, err := Confirm(context.Background(), true, strings.NewReader(answer), io.Discard); !errors.Is(err, ErrNotConfirmed) {
+			t.Fatalf("answer %q: want ErrNotConfirmed, got %v", answer, err)
+		}
+	}
+
+	ctx, err := Confirm(context.Background(), true, strings.NewReader("staging\n"), io.Discard)
+	if err != nil {
+		t.Fatalf("confirmed: %v", err)
+	}
+	if err := EnsureSafeEnv(ctx); err != nil {
+		t.Fatalf("confirmed context: want safe, got %v", err)
+	}
+
+	// 确认只对确认时的环境有效
+	config.AppEnv = "production"
+	if err := EnsureSafeEnv(ctx); !errors.Is(err, ErrUnsafeEnv) {
+		t.Fatalf("confirmed for another env: want ErrUnsafeEnv, got %v", err)
+	}
+}
+
+func TestConfirmEnvNotSet(t *testing.T) {
+	setAppEnv(t, "")
+
+	if IsSafeEnv() {
+		t.Fatal("unset APP_ENV should not be safe")
+	}
+	if err := EnsureSafeEnv(context.Background()); !errors.Is(err, ErrEnvNotSet) {
+		t.Fatalf("unset APP_ENV: want ErrEnvNotSet, got %v", err)
+	}
+	// 空输入与空的 APP_ENV 相同，也不能通过确认
+	for _, answer := range []string{"", "\n"} {
+		if _, err := Confirm(context.Background(), true, strings.NewReader(answer), io.Discard); !errors.Is(err, ErrEnvNotSet) {
+			t.Fatalf("answer %q with unset APP_ENV: want ErrEnvNotSet, got %v", answer, err)
+		}
+	}
+}
+
+// countUsers 统计 users 表中的行数
+func countUsers(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+
+	var count int64
+	if err := db.Model(&model.User{}).Count(&count).Error; err != nil {
+		t.Fatalf("count users: %v", err)
+	}
+	return count
+}
+
+func TestApplyFixturesIdempotent(t *testing.T) {
+	setAppEnv(t, "test")
+	db := dbtest.New(t)
+	ctx := context.Background()
+
+	fixtures, err := LoadFixtureFile("../../../../fixtures/users.yaml")
+	if err != nil {
+		t.Fatalf("load fixtures: %v", err)
+	}
+	rows := int64(len(fixtures[0].Rows))
+
+	for i := 0; i < 2; i++ {
+		if _, err := ApplyFixtures(ctx, fixtures); err != nil {
+			t.Fatalf("apply fixtures #%d: %v", i+1, err)
+		}
+		if count := countUsers(t, db); count != rows {
+			t.Fatalf("apply fixtures #%d: want %d users, got %d", i+1, rows, count)
+		}
+	}
+
+	// 修改后重新执行，按唯一键更新已有的行
+	fixtures[0].Rows[0]["name"] = "devadmin2"
+	if _, err := ApplyFixtures(ctx, fixtures); err != nil {
+		t.Fatalf("apply changed fixtures: %v", err)
+	}
+	if count := countUsers(t, db); count != rows {
+		t.Fatalf("apply changed fixtures: want %d users, got %d", rows, count)
+	}
+	var user model.User
+	if err := db.Where("email = ?", "devadmin@example.com").First(&user).Error; err != nil || user.Name != "devadmin2" {
+		t.Fatalf("changed fixture not applied: user=%+v err=%v", user, err)
+	}
+}
+
+func TestSeedUsersIdempotent(t *testing.T) {
+	setAppEnv(t, "test")
+	db := dbtest.New(t)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := SeedUsers(ctx, FakeUsers(20261018, 5)); err != nil {
+			t.Fatalf("seed users #%d: %v", i+1, err)
+		}
+		if count := countUsers(t, db); count != 5 {
+			t.Fatalf("seed users #%d: want 5 users, got %d", i+1, count)
+		}
+	}
+
+	// 不同的随机种子生成相同的邮箱，仍按邮箱更新
+	if _, err := SeedUsers(ctx, FakeUsers(1, 5)); err != nil {
+		t.Fatalf("seed users with another seed: %v", err)
+	}
+	if count := countUsers(t, db); count != 5 {
+		t.Fatalf("seed users with another seed: want 5 users, got %d", count)
+	}
+}
+
+func TestSeedRefusedInUnsafeEnv(t *testing.T) {
+	setAppEnv(t, "production")
+	db := dbtest.New(t)
+
+	if _, err := SeedUsers(context.Background(), FakeUsers(20261018, 1)); !errors.Is(err, ErrUnsafeEnv) {
+		t.Fatalf("seed users in production: want ErrUnsafeEnv, got %v", err)
+	}
+	if count := countUsers(t, db); count != 0 {
+		t.Fatalf("seed users in production wrote %d users", count)
+	}
+}
@@ -0,0 +1,233 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+
+	"github.com/hcd233/go-backend-tmpl/internal/constant"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	tenantSettingKey       = "app.tenant_id"
+	tenantBypassSettingKey = "app.bypass_tenant"
+
+	tenantPolicyName = "tenant_isolation"
+
+	tenantConnSettingKey = "tenant:conn"
+	tenantPoolSettingKey = "tenant:pool"
+)
+
+// WithTenant 将租户ID写入上下文
+//
+//	param ctx context.Context
+//	param tenantID uint
+//	return context.Context
+//	author centonhuang
+//	update 2026-10-18 10:12:03
+func WithTenant(ctx context.Context, tenantID uint) context.Context {
+	return context.WithValue(ctx, constant.CtxKeyTenantID, tenantID)
+}
+
+// WithoutTenantScope 跳过租户隔离，仅用于管理后台、迁移、定时任务等明确需要跨租户访问的场景
+//
+//	param ctx context.Context
+//	return context.Context
+//	author centonhuang
+//	update 2026-10-18 10:12:09
+func WithoutTenantScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, constant.CtxKeySkipTenantScope, true)
+}
+
+// TenantFromContext 从上下文获取租户ID
+//
+//	param ctx context.Context
+//	return tenantID uint
+//	return ok bool
+//	author centonhuang
+//	update 2026-10-18 10:12:15
+func TenantFromContext(ctx context.Context) (tenantID uint, ok bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	tenantID, ok = ctx.Value(constant.CtxKeyTenantID).(uint)
+	return
+}
+
+// IsTenantScopeSkipped 判断上下文是否显式跳过租户隔离
+//
+//	param ctx context.Context
+//	return bool
+//	author centonhuang
+//	update 2026-10-18 10:12:21
+func IsTenantScopeSkipped(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	skipped, _ := ctx.Value(constant.CtxKeySkipTenantScope).(bool)
+	return skipped
+}
+
+// EnableTenantRLS 为租户隔离模型对应的表开启Postgres行级安全策略
+//
+//	param db *gorm.DB
+//	param models ...interface{}
+//	return error
+//	author centonhuang
+//	update 2026-10-18 10:12:27
+func EnableTenantRLS(db *gorm.DB, models ...interface{}) error {
+	for _, m := range models {
+		if !model.IsTenantScoped(m) {
+			continue
+		}
+
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(m); err != nil {
+			return err
+		}
+		table := db.Statement.Quote(stmt.Schema.Table)
+		predicate := fmt.Sprintf("current_setting('%s', true) = 'on' OR tenant_id = NULLIF(current_setting('%s', true), '')::bigint",
+			tenantBypassSettingKey, tenantSettingKey)
+
+		sqls := []string{
+			fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", table),
+			fmt.Sprintf("ALTER TABLE %s FORCE ROW LEVEL SECURITY", table),
+			fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s", tenantPolicyName, table),
+			fmt.Sprintf("CREATE POLICY %s ON %s USING (%s) WITH CHECK (%s)", tenantPolicyName, table, predicate, predicate),
+		}
+		for _, sql := range sqls {
+			if err := db.Exec(sql).Error; err != nil {
+				return err
+			}
+		}
+
+		logger.Logger().Info("[Database] Tenant RLS policy enabled", zap.String("table", stmt.Schema.Table))
+	}
+	return nil
+}
+
+// tenantRLSPlugin 将上下文中的租户写入Postgres会话变量，配合 EnableTenantRLS 创建的策略生效
+//
+//	author centonhuang
+//	update 2026-10-18 10:12:33
+type tenantRLSPlugin struct{}
+
+// Name 插件名
+//
+//	receiver p *tenantRLSPlugin
+//	return string
+//	author centonhuang
+//	update 2026-10-18 10:12:39
+func (p *tenantRLSPlugin) Name() string {
+	return "tenant_rls"
+}
+
+// Initialize 注册回调
+//
+//	receiver p *tenantRLSPlugin
+//	param db *gorm.DB
+//	return error
+//	author centonhuang
+//	update 2026-10-18 10:12:45
+func (p *tenantRLSPlugin) Initialize(db *gorm.DB) error {
+	// 写操作默认在事务中执行，事务开启后设置事务级变量即可
+	if err := db.Callback().Create().After("gorm:begin_transaction").Register("tenant:set_session", p.setTxSession); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:begin_transaction").Register("tenant:set_session", p.setTxSession); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().After("gorm:begin_transaction").Register("tenant:set_session", p.setTxSession); err != nil {
+		return err
+	}
+
+	// 读操作没有事务，需要独占一个连接设置会话变量，查询完成后复位并归还；需在读写分离选定连接池之后执行
+	if err := db.Callback().Query().Before("gorm:query").After("gorm:db_resolver").Register("tenant:acquire_conn", p.acquireConn); err != nil {
+		return err
+	}
+	return db.Callback().Query().After("*").Register("tenant:release_conn", p.releaseConn)
+}
+
+func (p *tenantRLSPlugin) sessionValues(ctx context.Context) (tenantID, bypass string) {
+	if id, ok := TenantFromContext(ctx); ok {
+		tenantID = strconv.FormatUint(uint64(id), 10)
+	}
+	if IsTenantScopeSkipped(ctx) {
+		bypass = "on"
+	}
+	return
+}
+
+func (p *tenantRLSPlugin) setTxSession(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	if _, ok := db.Statement.ConnPool.(*sql.Tx); !ok {
+		return
+	}
+	p.setSession(db, db.Statement.ConnPool, true)
+}
+
+func (p *tenantRLSPlugin) acquireConn(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+
+	if _, ok := db.Statement.ConnPool.(*sql.Tx); ok {
+		p.setSession(db, db.Statement.ConnPool, true)
+		return
+	}
+
+	pool, ok := db.Statement.ConnPool.(interface {
+		Conn(ctx context.Context) (*sql.Conn, error)
+	})
+	if !ok {
+		return
+	}
+
+	conn, err := pool.Conn(db.Statement.Context)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+
+	db.Statement.Settings.Store(tenantConnSettingKey, conn)
+	db.Statement.Settings.Store(tenantPoolSettingKey, db.Statement.ConnPool)
+	db.Statement.ConnPool = conn
+
+	p.setSession(db, conn, false)
+}
+
+func (p *tenantRLSPlugin) releaseConn(db *gorm.DB) {
+	value, ok := db.Statement.Settings.LoadAndDelete(tenantConnSettingKey)
+	if !ok {
+		return
+	}
+	conn := value.(*sql.Conn)
+
+	if pool, ok := db.Statement.Settings.LoadAndDelete(tenantPoolSettingKey); ok {
+		db.Statement.ConnPool = pool.(gorm.ConnPool)
+	}
+
+	// 连接会回到连接池，必须清空会话变量避免串租户
+	if _, err := conn.ExecContext(context.Background(), "SELECT set_config($1, '', false), set_config($2, '', false)",
+		tenantSettingKey, tenantBypassSettingKey); err != nil {
+		logger.WithCtx(db.Statement.Context).Error("[Database] failed to reset tenant session", zap.Error(err))
+		_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+	}
+	_ = conn.Close()
+}
+
+func (p *tenantRLSPlugin) setSession(db *gorm.DB, pool gorm.ConnPool, local bool) {
+	tenantID, bypass := p.sessionValues(db.Statement.Context)
+	if _, err := pool.ExecContext(db.Statement.Context, "SELECT set_config($1, $2, $5), set_config($3, $4, $5)",
+		tenantSettingKey, tenantID, tenantBypassSettingKey, bypass, local); err != nil {
+		_ = db.AddError(err)
+	}
+}
@@ -0,0 +1,163 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/hcd233/go-backend-tmpl/internal/constant"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	txMaxRetries   = 3
+	txRetryBackoff = 50 * time.Millisecond
+)
+
+// ErrAfterCommitHook 事务已提交，但提交后回调执行失败
+//
+//	update 2026-10-18 14:10:08
+var ErrAfterCommitHook = errors.New("after commit hook failed")
+
+// txState 上下文中的事务状态，嵌套事务各自持有一份，提交成功后把回调交给外层
+type txState struct {
+	tx          *gorm.DB
+	afterCommit []func(ctx context.Context) error
+}
+
+// WithTx 在事务中执行 fn，fn 收到的上下文携带事务，经 GetDBInstance(ctx) 拿到的都是同一个事务
+//
+//	已在事务中时使用保存点实现嵌套，内层失败只回滚到保存点；最外层遇到序列化失败或死锁时整体重试。
+//	fn 可能被执行多次，不要在其中做不可重入的外部操作，这类操作应通过 AfterCommit 注册。
+//
+//	param ctx context.Context
+//	param fn func(ctx context.Context) error
+//	param opts ...*sql.TxOptions
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 14:10:14
+func WithTx(ctx context.Context, fn func(ctx context.Context) error, opts ...*sql.TxOptions) (err error) {
+	if parent, ok := txFromContext(ctx); ok {
+		return withSavepoint(ctx, parent, fn)
+	}
+
+	var state *txState
+	for attempt := 0; ; attempt++ {
+		state = &txState{}
+		err = GetDBInstance(ctx).Transaction(func(tx *gorm.DB) error {
+			state.tx = tx
+			return fn(context.WithValue(ctx, constant.CtxKeyDBTx, state))
+		}, opts...)
+
+		if err == nil || attempt >= txMaxRetries || !IsRetryableTxError(err) {
+			break
+		}
+
+		backoff := txRetryBackoff * time.Duration(1<<attempt)
+		logger.WithCtx(ctx).Warn("[Database] transaction conflict, retrying",
+			zap.Int("attempt", attempt+1), zap.Duration("backoff", backoff), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+	}
+	if err != nil {
+		return
+	}
+
+	return runAfterCommit(ctx, state.afterCommit)
+}
+
+// AfterCommit 注册事务提交后执行的回调，用于对象存储、缓存、消息等非数据库副作用
+//
+//	事务回滚时回调被丢弃；不在事务中时立即执行
+//
+//	param ctx context.Context
+//	param hook func(ctx context.Context) error
+//	return error 不在事务中时为回调的执行结果
+//	author centonhuang
+//	update 2026-10-18 14:10:20
+func AfterCommit(ctx context.Context, hook func(ctx context.Context) error) error {
+	if state, ok := txFromContext(ctx); ok {
+		state.afterCommit = append(state.afterCommit, hook)
+		return nil
+	}
+	return runAfterCommit(ctx, []func(ctx context.Context) error{hook})
+}
+
+// InTx 判断上下文是否处于事务中
+//
+//	param ctx context.Context
+//	return bool
+//	author centonhuang
+//	update 2026-10-18 14:10:26
+func InTx(ctx context.Context) bool {
+	_, ok := txFromContext(ctx)
+	return ok
+}
+
+// IsRetryableTxError 判断错误是否为可重试的事务冲突：序列化失败、死锁、锁等待超时
+//
+//	param err error
+//	return bool
+//	author centonhuang
+//	update 2026-10-18 14:10:32
+func IsRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// 40001 serialization_failure, 40P01 deadlock_detected
+		return pgErr.Code == "40001" || pgErr.Code == "40P01"
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		// 1213 ER_LOCK_DEADLOCK, 1205 ER_LOCK_WAIT_TIMEOUT
+		return mysqlErr.Number == 1213 || mysqlErr.Number == 1205
+	}
+
+	return err != nil && (strings.Contains(err.Error(), "SQLITE_BUSY") || strings.Contains(err.Error(), "database is locked"))
+}
+
+func txFromContext(ctx context.Context) (*txState, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	state, ok := ctx.Value(constant.CtxKeyDBTx).(*txState)
+	return state, ok
+}
+
+func withSavepoint(ctx context.Context, parent *txState, fn func(ctx context.Context) error) error {
+	state := &txState{}
+	err := parent.tx.Transaction(func(tx *gorm.DB) error {
+		state.tx = tx
+		return fn(context.WithValue(ctx, constant.CtxKeyDBTx, state))
+	})
+	if err != nil {
+		return err
+	}
+
+	parent.afterCommit = append(parent.afterCommit, state.afterCommit...)
+	return nil
+}
+
+func runAfterCommit(ctx context.Context, hooks []func(ctx context.Context) error) error {
+	var errs []error
+	for _, hook := range hooks {
+		if err := hook(ctx); err != nil {
+			logger.WithCtx(ctx).Error("[Database] after commit hook failed", zap.Error(err))
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrAfterCommitHook, errors.Join(errs...))
+	}
+	return nil
+}
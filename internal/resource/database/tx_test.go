@@ -0,0 +1,206 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/dbtest"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+var errBoom = errors.New("boom")
+
+func createUser(ctx context.Context, name string) error {
+	return database.GetDBInstance(ctx).Create(&model.User{
+		Name:         name,
+		Email:        name + "@example.com",
+		GithubBindID: "github-" + name,
+		QQBindID:     "qq-" + name,
+		GoogleBindID: "google-" + name,
+	}).Error
+}
+
+func userExists(t *testing.T, db *gorm.DB, name string) bool {
+	t.Helper()
+
+	var count int64
+	if err := db.Model(&model.User{}).Where("name = ?", name).Count(&count).Error; err != nil {
+		t.Fatalf("count user %s: %v", name, err)
+	}
+	return count > 0
+}
+
+func TestWithTxNestedSavepointRollback(t *testing.T) {
+	db := dbtest.New(t)
+	ctx := context.Background()
+
+	err := database.WithTx(ctx, func(ctx context.Context) error {
+		if err := createUser(ctx, "outer"); err != nil {
+			return err
+		}
+
+		innerErr := database.WithTx(ctx, func(ctx context.Context) error {
+			if err := createUser(ctx, "inner"); err != nil {
+				return err
+			}
+			return errBoom
+		})
+		if !errors.Is(innerErr, errBoom) {
+			return fmt.Errorf("want inner error %v, got %v", errBoom, innerErr)
+		}
+
+		// 内层只回滚到保存点，外层事务仍可继续写入
+		return createUser(ctx, "after")
+	})
+	if err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+
+	for name, want := range map[string]bool{"outer": true, "inner": false, "after": true} {
+		if got := userExists(t, db, name); got != want {
+			t.Errorf("user %s exists = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestWithTxRetryOnRetryableError(t *testing.T) {
+	db := dbtest.New(t)
+	ctx := context.Background()
+
+	attempts := 0
+	err := database.WithTx(ctx, func(ctx context.Context) error {
+		attempts++
+		if err := createUser(ctx, fmt.Sprintf("attempt%d", attempts)); err != nil {
+			return err
+		}
+		if attempts < 3 {
+			return &pgconn.PgError{Code: "40001", Message: "could not serialize access"}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+
+	// 失败的尝试整体回滚，只留下最后一次的写入
+	for name, want := range map[string]bool{"attempt1": false, "attempt2": false, "attempt3": true} {
+		if got := userExists(t, db, name); got != want {
+			t.Errorf("user %s exists = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestWithTxNoRetryOnOtherError(t *testing.T) {
+	dbtest.New(t)
+
+	attempts := 0
+	err := database.WithTx(context.Background(), func(context.Context) error {
+		attempts++
+		return errBoom
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("want %v, got %v", errBoom, err)
+	}
+	if attempts != 1 {
+		t.Fatalf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestAfterCommitRunsOnlyAfterOutermostCommit(t *testing.T) {
+	db := dbtest.New(t)
+	ctx := context.Background()
+
+	var ran []string
+	hook := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			if database.InTx(ctx) {
+				return fmt.Errorf("hook %s ran inside transaction", name)
+			}
+			// 回调执行时数据已提交，对事务外可见
+			if !userExists(t, db, "committed") {
+				return fmt.Errorf("hook %s ran before commit", name)
+			}
+			ran = append(ran, name)
+			return nil
+		}
+	}
+
+	err := database.WithTx(ctx, func(ctx context.Context) error {
+		if err := createUser(ctx, "committed"); err != nil {
+			return err
+		}
+		if err := database.AfterCommit(ctx, hook("outer")); err != nil {
+			return err
+		}
+
+		if err := database.WithTx(ctx, func(ctx context.Context) error {
+			return database.AfterCommit(ctx, hook("inner"))
+		}); err != nil {
+			return err
+		}
+		if len(ran) != 0 {
+			return fmt.Errorf("hooks ran before outermost commit: %v", ran)
+		}
+
+		// 回滚的保存点注册的回调被丢弃
+		_ = database.WithTx(ctx, func(ctx context.Context) error {
+			if err := database.AfterCommit(ctx, hook("rolledBack")); err != nil {
+				return err
+			}
+			return errBoom
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+	if fmt.Sprint(ran) != "[outer inner]" {
+		t.Fatalf("ran hooks = %v, want [outer inner]", ran)
+	}
+}
+
+func TestAfterCommitSkippedOnRollback(t *testing.T) {
+	dbtest.New(t)
+
+	ran := false
+	err := database.WithTx(context.Background(), func(ctx context.Context) error {
+		if err := database.AfterCommit(ctx, func(context.Context) error {
+			ran = true
+			return nil
+		}); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("want %v, got %v", errBoom, err)
+	}
+	if ran {
+		t.Fatal("after commit hook ran after rollback")
+	}
+}
+
+func TestAfterCommitHookErrorKeepsCommit(t *testing.T) {
+	db := dbtest.New(t)
+
+	err := database.WithTx(context.Background(), func(ctx context.Context) error {
+		if err := createUser(ctx, "kept"); err != nil {
+			return err
+		}
+		return database.AfterCommit(ctx, func(context.Context) error { return errBoom })
+	})
+	if !errors.Is(err, database.ErrAfterCommitHook) || !errors.Is(err, errBoom) {
+		t.Fatalf("want ErrAfterCommitHook wrapping %v, got %v", errBoom, err)
+	}
+	if !userExists(t, db, "kept") {
+		t.Fatal("transaction was not committed before the failing hook")
+	}
+}
@@ -4,22 +4,37 @@
 package llm
 
 import (
+	"context"
+	"net/http"
+
 	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/health"
 	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/tracing"
 	openai "github.com/sashabaranov/go-openai"
 	"go.uber.org/zap"
 )
 
 var client *openai.Client
 
+// httpClient OpenAI 客户端使用的 HTTP 客户端，关闭时释放空闲连接
+var httpClient *http.Client
+
 // InitOpenAIClient 初始化OpenAI客户端
 //
 //	author centonhuang
-//	update 2024-12-09 16:00:26
+//	update 2026-10-18 17:51:14
 func InitOpenAIClient() {
+	httpClient = &http.Client{Transport: tracing.NewTransport(http.DefaultTransport.(*http.Transport).Clone(), "openai")}
+
 	clientConfig := openai.DefaultConfig(config.OpenAIAPIKey)
 	clientConfig.BaseURL = config.OpenAIBaseURL
+	clientConfig.HTTPClient = httpClient
 	client = openai.NewClientWithConfig(clientConfig)
+	health.Register("llm", func(ctx context.Context) error {
+		_, err := client.ListModels(ctx)
+		return err
+	})
 
 	logger.Logger().Info("[OpenAI] Connected to OpenAI API", zap.String("baseURL", config.OpenAIBaseURL))
 }
@@ -32,3 +47,16 @@ func InitOpenAIClient() {
 func GetOpenAIClient() *openai.Client {
 	return client
 }
+
+// CloseOpenAIClient 关闭 OpenAI 客户端的空闲连接
+//
+//	param ctx context.Context
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:43:20
+func CloseOpenAIClient(context.Context) error {
+	if httpClient != nil {
+		httpClient.CloseIdleConnections()
+	}
+	return nil
+}
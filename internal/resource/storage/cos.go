@@ -7,7 +7,9 @@ import (
 	"net/url"
 
 	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/health"
 	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/tracing"
 	"github.com/samber/lo"
 	"github.com/tencentyun/cos-go-sdk-v5"
 	"go.uber.org/zap"
@@ -26,13 +28,19 @@ func initCosClient() {
 		SecretID:  config.CosSecretID,
 		SecretKey: config.CosSecretKey,
 	}
+	transport = http.DefaultTransport.(*http.Transport).Clone()
 	cosClient = cos.NewClient(uri, &http.Client{
-		Transport: &cos.CredentialTransport{
+		Transport: tracing.NewTransport(&cos.CredentialTransport{
 			Credential: credential,
-		},
+			Transport:  transport,
+		}, "cos"),
 	})
 
 	_, _ = lo.Must2(cosClient.Bucket.Get(context.Background(), &cos.BucketGetOptions{}))
+	health.Register("storage", func(ctx context.Context) error {
+		_, err := cosClient.Bucket.Head(ctx)
+		return err
+	})
 
 	logger.Logger().Info("[Object Storage] Connected to COS", zap.String("endpoint", endpoint.String()))
 }
@@ -7,7 +7,9 @@ import (
 	"context"
 
 	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/health"
 	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/tracing"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/samber/lo"
@@ -17,13 +19,19 @@ import (
 var minioClient *minio.Client
 
 func initMinioClient() {
+	transport = lo.Must1(minio.DefaultTransport(config.MinioTLS))
 	minioClient = lo.Must1(minio.New(config.MinioEndpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(config.MinioAccessID, config.MinioAccessKey, ""),
-		Secure: config.MinioTLS,
-		Region: config.MinioRegion,
+		Creds:     credentials.NewStaticV4(config.MinioAccessID, config.MinioAccessKey, ""),
+		Secure:    config.MinioTLS,
+		Region:    config.MinioRegion,
+		Transport: tracing.NewTransport(transport, "minio"),
 	}))
 
 	_ = lo.Must1(minioClient.ListBuckets(context.Background()))
+	health.Register("storage", func(ctx context.Context) error {
+		_, err := minioClient.ListBuckets(ctx)
+		return err
+	})
 
 	logger.Logger().Info("[Object Storage] Connected to Minio", zap.String("endpoint", config.MinioEndpoint))
 }
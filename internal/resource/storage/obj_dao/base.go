@@ -23,6 +23,18 @@ type ObjDAO interface {
 	DeleteObject(ctx context.Context, userID uint, objectName string) (err error)
 }
 
+// BackupObjDAO 数据库备份对象存储DAO接口，备份不属于任何用户，统一存放在 backup/ 前缀下
+//
+//	author centonhuang
+//	update 2026-10-18 11:40:18
+type BackupObjDAO interface {
+	GetBucketName(ctx context.Context) string
+	UploadFile(ctx context.Context, objectName string, filePath string) (err error)
+	DownloadFile(ctx context.Context, objectName string, filePath string) (err error)
+	ListObjects(ctx context.Context) (objectInfos []ObjectInfo, err error)
+	DeleteObject(ctx context.Context, objectName string) (err error)
+}
+
 // ObjectType 对象类型
 //
 //	author centonhuang
@@ -38,12 +50,17 @@ const (
 	//	update 2025-01-05 17:36:05
 	ObjectTypeThumbnail ObjectType = "thumbnail"
 
+	// ObjectTypeBackup ObjectType
+	//	update 2026-10-18 11:40:12
+	ObjectTypeBackup ObjectType = "backup"
+
 	createBucketTimeout   = 10 * time.Second
 	listObjectsTimeout    = 10 * time.Second
 	uploadObjectTimeout   = 30 * time.Second
 	downloadObjectTimeout = 30 * time.Second
 	deleteObjectTimeout   = 10 * time.Second
 	presignObjectTimeout  = 10 * time.Second
+	backupObjectTimeout   = 30 * time.Minute
 
 	presignObjectExpire = 5 * time.Minute
 )
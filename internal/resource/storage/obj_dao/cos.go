@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hcd233/go-backend-tmpl/internal/resource/storage"
 	"github.com/samber/lo"
 	"github.com/tencentyun/cos-go-sdk-v5"
 )
@@ -195,3 +196,123 @@ func (dao *CosObjDAO) DeleteObject(ctx context.Context, userID uint, objectName
 	_, err = dao.client.Object.Delete(ctx, objectName)
 	return
 }
+
+// CosBackupObjDAO 腾讯云COS数据库备份DAO
+//
+//	author centonhuang
+//	update 2026-10-18 11:44:02
+type CosBackupObjDAO struct {
+	BucketName string
+}
+
+func (dao *CosBackupObjDAO) client() *cos.Client {
+	return storage.GetCosClient()
+}
+
+func (dao *CosBackupObjDAO) composeObjectName(objectName string) string {
+	return path.Join(string(ObjectTypeBackup), objectName)
+}
+
+// GetBucketName 获取桶名
+//
+//	receiver dao *CosBackupObjDAO
+//	param ctx context.Context
+//	return string
+//	author centonhuang
+//	update 2026-10-18 11:44:08
+func (dao *CosBackupObjDAO) GetBucketName(_ context.Context) string {
+	return dao.BucketName
+}
+
+// UploadFile 上传本地备份文件
+//
+//	receiver dao *CosBackupObjDAO
+//	param ctx context.Context
+//	param objectName string
+//	param filePath string
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 11:44:14
+func (dao *CosBackupObjDAO) UploadFile(ctx context.Context, objectName string, filePath string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, backupObjectTimeout)
+	defer cancel()
+
+	_, err = dao.client().Object.PutFromFile(ctx, dao.composeObjectName(objectName), filePath, nil)
+	return
+}
+
+// DownloadFile 下载备份到本地文件
+//
+//	receiver dao *CosBackupObjDAO
+//	param ctx context.Context
+//	param objectName string
+//	param filePath string
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 11:44:20
+func (dao *CosBackupObjDAO) DownloadFile(ctx context.Context, objectName string, filePath string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, backupObjectTimeout)
+	defer cancel()
+
+	_, err = dao.client().Object.GetToFile(ctx, dao.composeObjectName(objectName), filePath, nil)
+	return
+}
+
+// ListObjects 列出所有备份
+//
+//	receiver dao *CosBackupObjDAO
+//	param ctx context.Context
+//	return objectInfos []ObjectInfo
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 11:44:26
+func (dao *CosBackupObjDAO) ListObjects(ctx context.Context) (objectInfos []ObjectInfo, err error) {
+	dirName := string(ObjectTypeBackup) + "/"
+
+	ctx, cancel := context.WithTimeout(ctx, listObjectsTimeout)
+	defer cancel()
+
+	opt := &cos.BucketGetOptions{
+		Prefix:  dirName,
+		MaxKeys: 1000,
+	}
+
+	for {
+		result, _, err := dao.client().Bucket.Get(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range result.Contents {
+			lastModified, _ := time.ParseInLocation(time.RFC3339, object.LastModified, time.UTC)
+
+			objectInfos = append(objectInfos, ObjectInfo{
+				ObjectName:   strings.TrimPrefix(object.Key, dirName),
+				Size:         object.Size,
+				LastModified: lastModified,
+				ETag:         strings.Trim(object.ETag, "\""),
+			})
+		}
+
+		if !result.IsTruncated {
+			return objectInfos, nil
+		}
+		opt.Marker = result.NextMarker
+	}
+}
+
+// DeleteObject 删除备份
+//
+//	receiver dao *CosBackupObjDAO
+//	param ctx context.Context
+//	param objectName string
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 11:44:32
+func (dao *CosBackupObjDAO) DeleteObject(ctx context.Context, objectName string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, deleteObjectTimeout)
+	defer cancel()
+
+	_, err = dao.client().Object.Delete(ctx, dao.composeObjectName(objectName))
+	return
+}
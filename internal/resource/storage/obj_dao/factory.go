@@ -0,0 +1,61 @@
+package objdao
+
+import (
+	"fmt"
+
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/storage"
+)
+
+// NewObjDAO 按配置的存储提供商创建对象DAO，需在 storage.InitObjectStorage 之后调用
+//
+//	param objectType ObjectType
+//	return ObjDAO
+//	return error 未配置对象存储时返回 storage.ErrNoObjectStorage
+//	author centonhuang
+//	update 2026-10-18 18:02:14
+func NewObjDAO(objectType ObjectType) (ObjDAO, error) {
+	provider, err := storage.GetProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	switch provider {
+	case storage.ProviderMinio:
+		return &MinioObjDAO{
+			ObjectType: objectType,
+			BucketName: config.MinioBucketName,
+			client:     storage.GetMinioStorage(),
+		}, nil
+	case storage.ProviderCOS:
+		return &CosObjDAO{
+			ObjectType: objectType,
+			BucketName: config.CosBucketName,
+			client:     storage.GetCosClient(),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage provider: %s", provider)
+	}
+}
+
+// NewBackupObjDAO 按配置的存储提供商创建数据库备份对象DAO
+//
+//	return BackupObjDAO
+//	return error 未配置对象存储时返回 storage.ErrNoObjectStorage
+//	author centonhuang
+//	update 2026-10-18 18:02:20
+func NewBackupObjDAO() (BackupObjDAO, error) {
+	provider, err := storage.GetProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	switch provider {
+	case storage.ProviderMinio:
+		return &MinioBackupObjDAO{BucketName: config.MinioBucketName}, nil
+	case storage.ProviderCOS:
+		return &CosBackupObjDAO{BucketName: config.CosBucketName}, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage provider: %s", provider)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hcd233/go-backend-tmpl/internal/resource/storage"
 	"github.com/minio/minio-go/v7"
 	"github.com/samber/lo"
 )
@@ -244,3 +245,112 @@ func (dao *MinioObjDAO) DeleteObject(ctx context.Context, userID uint, objectNam
 	err = dao.client.RemoveObject(ctx, dao.BucketName, objectName, minio.RemoveObjectOptions{})
 	return
 }
+
+// MinioBackupObjDAO Minio数据库备份DAO
+//
+//	author centonhuang
+//	update 2026-10-18 11:42:03
+type MinioBackupObjDAO struct {
+	BucketName string
+}
+
+func (dao *MinioBackupObjDAO) client() *minio.Client {
+	return storage.GetMinioStorage()
+}
+
+func (dao *MinioBackupObjDAO) composeObjectName(objectName string) string {
+	return path.Join(string(ObjectTypeBackup), objectName)
+}
+
+// GetBucketName 获取桶名
+//
+//	receiver dao *MinioBackupObjDAO
+//	param ctx context.Context
+//	return string
+//	author centonhuang
+//	update 2026-10-18 11:42:09
+func (dao *MinioBackupObjDAO) GetBucketName(_ context.Context) string {
+	return dao.BucketName
+}
+
+// UploadFile 上传本地备份文件
+//
+//	receiver dao *MinioBackupObjDAO
+//	param ctx context.Context
+//	param objectName string
+//	param filePath string
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 11:42:15
+func (dao *MinioBackupObjDAO) UploadFile(ctx context.Context, objectName string, filePath string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, backupObjectTimeout)
+	defer cancel()
+
+	_, err = dao.client().FPutObject(ctx, dao.BucketName, dao.composeObjectName(objectName), filePath, minio.PutObjectOptions{})
+	return
+}
+
+// DownloadFile 下载备份到本地文件
+//
+//	receiver dao *MinioBackupObjDAO
+//	param ctx context.Context
+//	param objectName string
+//	param filePath string
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 11:42:21
+func (dao *MinioBackupObjDAO) DownloadFile(ctx context.Context, objectName string, filePath string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, backupObjectTimeout)
+	defer cancel()
+
+	err = dao.client().FGetObject(ctx, dao.BucketName, dao.composeObjectName(objectName), filePath, minio.GetObjectOptions{})
+	return
+}
+
+// ListObjects 列出所有备份
+//
+//	receiver dao *MinioBackupObjDAO
+//	param ctx context.Context
+//	return objectInfos []ObjectInfo
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 11:42:27
+func (dao *MinioBackupObjDAO) ListObjects(ctx context.Context) (objectInfos []ObjectInfo, err error) {
+	dirName := string(ObjectTypeBackup) + "/"
+
+	ctx, cancel := context.WithTimeout(ctx, listObjectsTimeout)
+	defer cancel()
+
+	for object := range dao.client().ListObjects(ctx, dao.BucketName, minio.ListObjectsOptions{Prefix: dirName}) {
+		if object.Err != nil {
+			err = object.Err
+			return
+		}
+
+		objectInfos = append(objectInfos, ObjectInfo{
+			ObjectName:   strings.TrimPrefix(object.Key, dirName),
+			ContentType:  object.ContentType,
+			Size:         object.Size,
+			LastModified: object.LastModified,
+			Expires:      object.Expires,
+			ETag:         object.ETag,
+		})
+	}
+	return
+}
+
+// DeleteObject 删除备份
+//
+//	receiver dao *MinioBackupObjDAO
+//	param ctx context.Context
+//	param objectName string
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 11:42:33
+func (dao *MinioBackupObjDAO) DeleteObject(ctx context.Context, objectName string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, deleteObjectTimeout)
+	defer cancel()
+
+	err = dao.client().RemoveObject(ctx, dao.BucketName, dao.composeObjectName(objectName), minio.RemoveObjectOptions{})
+	return
+}
@@ -1,7 +1,12 @@
 package storage
 
 import (
+	"context"
+	"errors"
+	"net/http"
+
 	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/samber/lo"
 )
 
 // Provider 存储提供商
@@ -14,14 +19,22 @@ const (
 	ProviderCOS Provider = "cos"
 )
 
+// ErrNoObjectStorage 未配置任何对象存储
+//
+//	update 2026-10-18 18:03:02
+var ErrNoObjectStorage = errors.New("no object storage configured")
+
 var provider Provider
 
+// transport 对象存储客户端使用的连接池，关闭时释放空闲连接
+var transport *http.Transport
+
 // InitObjectStorage 初始化对象存储
 //
 //	author centonhuang
-//	update 2024-12-09 15:59:06
+//	update 2026-10-18 18:03:08
 func InitObjectStorage() {
-	provider = GetProvider()
+	provider = lo.Must1(GetProvider())
 
 	switch provider {
 	case ProviderMinio:
@@ -34,17 +47,31 @@ func InitObjectStorage() {
 // GetProvider 获取存储提供商
 //
 //	return Provider
+//	return error 未配置对象存储时返回 ErrNoObjectStorage
 //	author centonhuang
-//	update 2025-01-19 14:13:22
-func GetProvider() Provider {
+//	update 2026-10-18 18:03:14
+func GetProvider() (Provider, error) {
 	// 优先使用 COS
 	if config.CosAppID != "" {
-		return ProviderCOS
+		return ProviderCOS, nil
 	}
 
 	if config.MinioEndpoint != "" {
-		return ProviderMinio
+		return ProviderMinio, nil
 	}
 
-	panic("no object storage configured")
+	return "", ErrNoObjectStorage
+}
+
+// CloseObjectStorage 关闭对象存储客户端的空闲连接，客户端本身没有需要释放的资源
+//
+//	param ctx context.Context
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:43:02
+func CloseObjectStorage(context.Context) error {
+	if transport != nil {
+		transport.CloseIdleConnections()
+	}
+	return nil
 }
@@ -2,12 +2,15 @@ package router
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/hcd233/go-backend-tmpl/internal/container"
 	"github.com/hcd233/go-backend-tmpl/internal/handler"
+	"github.com/samber/lo"
 )
 
-func initOauth2Router(r fiber.Router) {
-	githubOauth2Handler := handler.NewGithubOauth2Handler()
-	googleOauth2Handler := handler.NewGoogleOauth2Handler()
+func initOauth2Router(r fiber.Router, c *container.Container) {
+	// OAuth2 登录需要将头像写入对象存储，未配置对象存储时无法启动
+	githubOauth2Handler := handler.NewOauth2Handler(lo.Must1(c.GithubOauth2Service()))
+	googleOauth2Handler := handler.NewOauth2Handler(lo.Must1(c.GoogleOauth2Service()))
 
 	oauth2Group := r.Group("/oauth2")
 	{
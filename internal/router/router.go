@@ -4,25 +4,38 @@ package router
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/swagger"
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/container"
 	"github.com/hcd233/go-backend-tmpl/internal/handler"
+	"github.com/hcd233/go-backend-tmpl/internal/metrics"
 )
 
-// RegisterRouter 注册路由
+// RegisterRouter 注册路由，处理器依赖的服务从容器中获取
 //
 //	param app *fiber.App
+//	param c *container.Container
 //	author centonhuang
-//	update 2025-01-04 15:32:40
-func RegisterRouter(app *fiber.App) {
+//	update 2026-10-18 18:11:02
+func RegisterRouter(app *fiber.App, c *container.Container) {
 	// swagger
 	app.Get("/swagger/*", swagger.HandlerDefault)
 
+	// 未配置独立端口时指标挂载在 API 端口上
+	if config.MetricsEnabled && config.MetricsPort == "" {
+		app.Get("/metrics", metrics.Handler())
+	}
+
 	pingService := handler.NewPingHandler()
 	app.Get("/", pingService.HandlePing)
 
+	healthHandler := handler.NewHealthHandler()
+	app.Get("/healthz", healthHandler.HandleLiveness)
+	app.Get("/readyz", healthHandler.HandleReadiness)
+
 	v1Router := app.Group("/v1")
 	{
-		initTokenRouter(v1Router)
-		initOauth2Router(v1Router)
-		initUserRouter(v1Router)
+		initTokenRouter(v1Router, c)
+		initOauth2Router(v1Router, c)
+		initUserRouter(v1Router, c)
 	}
 }
@@ -2,21 +2,32 @@ package router
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/hcd233/go-backend-tmpl/internal/container"
 	"github.com/hcd233/go-backend-tmpl/internal/handler"
 	"github.com/hcd233/go-backend-tmpl/internal/middleware"
 	"github.com/hcd233/go-backend-tmpl/internal/protocol"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
 )
 
-func initUserRouter(r fiber.Router) {
-	userHandler := handler.NewUserHandler()
+func initUserRouter(r fiber.Router, c *container.Container) {
+	userHandler := handler.NewUserHandler(c.UserService())
+	rateLimitHandler := handler.NewRateLimitHandler(c.RateLimitService())
 
-	userRouter := r.Group("/user", middleware.JwtMiddleware())
+	userRouter := r.Group("/user", middleware.JwtMiddleware(c.UserDAO(), c.AccessTokenSigner()), middleware.ReadYourWritesMiddleware())
 	{
-		userRouter.Get("/current", userHandler.HandleGetCurUserInfo)
-		userRouter.Patch("/", middleware.ValidateBodyMiddleware(&protocol.UpdateUserBody{}), userHandler.HandleUpdateInfo)
+		userRouter.Get("/current", middleware.TieredRateLimiterMiddleware("getCurUserInfo", 1), userHandler.HandleGetCurUserInfo)
+		userRouter.Get("/", middleware.LimitUserPermissionMiddleware("listUsers", model.PermissionAdmin), middleware.TieredRateLimiterMiddleware("listUsers", 5), middleware.ValidateParamMiddleware(&protocol.ListParam{}), userHandler.HandleListUsers)
+		userRouter.Patch("/", middleware.IdempotencyMiddleware("updateUser"), middleware.TieredRateLimiterMiddleware("updateUser", 2), middleware.ValidateBodyMiddleware(&protocol.UpdateUserBody{}), userHandler.HandleUpdateInfo)
 		userNameRouter := userRouter.Group("/:userID", middleware.ValidateURIMiddleware(&protocol.UserURI{}))
 		{
-			userNameRouter.Get("/", userHandler.HandleGetUserInfo)
+			userNameRouter.Get("/", middleware.TieredRateLimiterMiddleware("getUserInfo", 1), userHandler.HandleGetUserInfo)
+
+			rateLimitRouter := userNameRouter.Group("/rateLimit", middleware.LimitUserPermissionMiddleware("rateLimitOverride", model.PermissionAdmin))
+			{
+				rateLimitRouter.Get("/", rateLimitHandler.HandleGetPolicy)
+				rateLimitRouter.Put("/", middleware.ValidateBodyMiddleware(&protocol.SetRateLimitOverrideBody{}), rateLimitHandler.HandleSetOverride)
+				rateLimitRouter.Delete("/", rateLimitHandler.HandleDeleteOverride)
+			}
 		}
 
 	}
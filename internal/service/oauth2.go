@@ -406,27 +406,46 @@ func (p *googleProvider) GetBindField() string {
 	return "google_bind_id"
 }
 
+// Oauth2Dependencies OAuth2服务依赖的DAO与令牌签名器
+//
+//	author centonhuang
+//	update 2026-10-18 18:04:20
+type Oauth2Dependencies struct {
+	UserDAO            *dao.UserDAO
+	ImageObjDAO        objdao.ObjDAO
+	ThumbnailObjDAO    objdao.ObjDAO
+	AccessTokenSigner  auth.JwtTokenSigner
+	RefreshTokenSigner auth.JwtTokenSigner
+}
+
 // NewGithubOauth2Service 创建Github OAuth2服务
-func NewGithubOauth2Service() Oauth2Service {
-	return &oauth2Service{
-		provider:           newGithubProvider(),
-		userDAO:            dao.GetUserDAO(),
-		imageObjDAO:        objdao.GetImageObjDAO(),
-		thumbnailObjDAO:    objdao.GetThumbnailObjDAO(),
-		accessTokenSigner:  auth.GetJwtAccessTokenSigner(),
-		refreshTokenSigner: auth.GetJwtRefreshTokenSigner(),
-	}
+//
+//	param deps Oauth2Dependencies
+//	return Oauth2Service
+//	author centonhuang
+//	update 2026-10-18 18:04:26
+func NewGithubOauth2Service(deps Oauth2Dependencies) Oauth2Service {
+	return newOauth2Service(newGithubProvider(), deps)
 }
 
 // NewGoogleOauth2Service 创建Google OAuth2服务
-func NewGoogleOauth2Service() Oauth2Service {
+//
+//	param deps Oauth2Dependencies
+//	return Oauth2Service
+//	author centonhuang
+//	update 2026-10-18 18:04:32
+func NewGoogleOauth2Service(deps Oauth2Dependencies) Oauth2Service {
+	return newOauth2Service(newGoogleProvider(), deps)
+}
+
+func newOauth2Service(provider OAuth2ProviderInterface, deps Oauth2Dependencies) Oauth2Service {
 	return &oauth2Service{
-		provider:           newGoogleProvider(),
-		userDAO:            dao.GetUserDAO(),
-		imageObjDAO:        objdao.GetImageObjDAO(),
-		thumbnailObjDAO:    objdao.GetThumbnailObjDAO(),
-		accessTokenSigner:  auth.GetJwtAccessTokenSigner(),
-		refreshTokenSigner: auth.GetJwtRefreshTokenSigner(),
+		provider:           provider,
+		userDAO:            deps.UserDAO,
+		imageObjDAO:        deps.ImageObjDAO,
+		thumbnailObjDAO:    deps.ThumbnailObjDAO,
+		accessTokenSigner:  deps.AccessTokenSigner,
+		refreshTokenSigner: deps.RefreshTokenSigner,
 	}
 }
 
@@ -449,7 +468,6 @@ func (s *oauth2Service) Callback(ctx context.Context, req *protocol.CallbackRequ
 	rsp = &protocol.CallbackResponse{}
 
 	logger := logger.WithCtx(ctx)
-	db := database.GetDBInstance(ctx)
 
 	if req.State != config.Oauth2StateString {
 		logger.Error("[Oauth2Service] invalid state",
@@ -483,22 +501,73 @@ func (s *oauth2Service) Callback(ctx context.Context, req *protocol.CallbackRequ
 	thirdPartyID := userInfo.GetID()
 	userName, email, avatar := userInfo.GetName(), userInfo.GetEmail(), userInfo.GetAvatar()
 
-	user, err := s.userDAO.GetByEmail(db, email, []string{"id", "name", "avatar"}, []string{})
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		logger.Error("[Oauth2Service] failed to get user by email",
+	// 查找/创建用户与更新绑定ID在同一个事务中完成，对象存储目录在提交后确保存在
+	var user *model.User
+	err = database.WithTx(ctx, func(ctx context.Context) error {
+		user, err = s.upsertUser(ctx, thirdPartyID, userName, email, avatar)
+		return err
+	})
+	if errors.Is(err, database.ErrAfterCommitHook) {
+		// 用户已落库，目录创建失败不影响登录，下次登录会重试
+		logger.Warn("[Oauth2Service] failed to ensure object storage dirs, continue login",
+			zap.Uint("userID", user.ID),
+			zap.Error(err))
+		err = nil
+	}
+	if err != nil {
+		logger.Error("[Oauth2Service] failed to upsert user",
 			zap.String("email", email),
 			zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
 
+	accessToken, err := s.accessTokenSigner.EncodeToken(user.ID)
+	if err != nil {
+		logger.Error("[Oauth2Service] failed to encode access token",
+			zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	refreshToken, err := s.refreshTokenSigner.EncodeToken(user.ID)
+	if err != nil {
+		logger.Error("[Oauth2Service] failed to encode refresh token",
+			zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.AccessToken = accessToken
+	rsp.RefreshToken = refreshToken
+
+	return rsp, nil
+}
+
+// upsertUser 按邮箱查找用户，不存在时创建，更新第三方平台绑定ID，并在事务提交后确保对象存储目录存在
+//
+//	receiver s *oauth2Service
+//	param ctx context.Context 携带事务的上下文
+//	param thirdPartyID string
+//	param userName string
+//	param email string
+//	param avatar string
+//	return user *model.User
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 20:12:04
+func (s *oauth2Service) upsertUser(ctx context.Context, thirdPartyID, userName, email, avatar string) (user *model.User, err error) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	user, err = s.userDAO.GetByEmail(db, email, []string{"id", "name", "avatar"}, []string{})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("get user by email: %w", err)
+	}
+
 	if user.ID != 0 {
 		// 更新已存在用户的登录时间
-		if err := s.userDAO.Update(db, user, map[string]interface{}{
+		if err = s.userDAO.Update(db, user, map[string]interface{}{
 			"last_login": time.Now().UTC(),
 		}); err != nil {
-			logger.Error("[Oauth2Service] failed to update user login time",
-				zap.Error(err))
-			return nil, protocol.ErrInternalError
+			return nil, fmt.Errorf("update user login time: %w", err)
 		}
 	} else {
 		// 创建新用户
@@ -514,60 +583,34 @@ func (s *oauth2Service) Callback(ctx context.Context, req *protocol.CallbackRequ
 			LastLogin:  time.Now().UTC(),
 		}
 
-		if err := s.userDAO.Create(db, user); err != nil {
-			logger.Error("[Oauth2Service] failed to create user",
-				zap.String("userName", userName),
-				zap.Error(err))
-			return nil, protocol.ErrInternalError
-		}
-
-		_, err = s.imageObjDAO.CreateDir(ctx, user.ID)
-		if err != nil {
-			logger.Error("[Oauth2Service] failed to create image dir",
-				zap.Error(err))
-			return nil, protocol.ErrInternalError
+		if err = s.userDAO.Create(db, user); err != nil {
+			return nil, fmt.Errorf("create user %s: %w", userName, err)
 		}
-		logger.Info("[Oauth2Service] image dir created")
-
-		_, err = s.thumbnailObjDAO.CreateDir(ctx, user.ID)
-		if err != nil {
-			logger.Error("[Oauth2Service] failed to create thumbnail dir",
-				zap.Error(err))
-			return nil, protocol.ErrInternalError
-		}
-		logger.Info("[Oauth2Service] thumbnail dir created")
 	}
 
 	// 更新第三方平台绑定ID
 	bindField := s.provider.GetBindField()
-	updateData := map[string]interface{}{
+	if err = s.userDAO.Update(db, user, map[string]interface{}{
 		bindField: thirdPartyID,
+	}); err != nil {
+		return nil, fmt.Errorf("update third party bind id %s: %w", bindField, err)
 	}
 
-	if err := s.userDAO.Update(db, user, updateData); err != nil {
-		logger.Error("[Oauth2Service] failed to update third party bind id",
-			zap.String("bindField", bindField),
-			zap.String("thirdPartyID", thirdPartyID),
-			zap.Error(err))
-		return nil, protocol.ErrInternalError
-	}
-
-	accessToken, err := s.accessTokenSigner.EncodeToken(user.ID)
-	if err != nil {
-		logger.Error("[Oauth2Service] failed to encode access token",
-			zap.Error(err))
-		return nil, protocol.ErrInternalError
-	}
+	// 每次登录都确保目录存在：创建目录是幂等的覆盖写，首次登录失败时下次登录会补建
+	userID := user.ID
+	if err = database.AfterCommit(ctx, func(ctx context.Context) error {
+		if _, err := s.imageObjDAO.CreateDir(ctx, userID); err != nil {
+			return fmt.Errorf("create image dir: %w", err)
+		}
+		logger.Info("[Oauth2Service] image dir ensured")
 
-	refreshToken, err := s.refreshTokenSigner.EncodeToken(user.ID)
-	if err != nil {
-		logger.Error("[Oauth2Service] failed to encode refresh token",
-			zap.Error(err))
-		return nil, protocol.ErrInternalError
+		if _, err := s.thumbnailObjDAO.CreateDir(ctx, userID); err != nil {
+			return fmt.Errorf("create thumbnail dir: %w", err)
+		}
+		logger.Info("[Oauth2Service] thumbnail dir ensured")
+		return nil
+	}); err != nil {
+		return nil, err
 	}
-
-	rsp.AccessToken = accessToken
-	rsp.RefreshToken = refreshToken
-
-	return rsp, nil
+	return user, nil
 }
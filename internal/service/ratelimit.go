@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/hcd233/go-backend-tmpl/internal/protocol"
+	"github.com/hcd233/go-backend-tmpl/internal/ratelimit"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/dao"
+	"github.com/hcd233/go-backend-tmpl/internal/resource/database/model"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// RateLimitService 限频策略服务
+//
+//	author centonhuang
+//	update 2026-10-18 16:25:02
+type RateLimitService interface {
+	GetPolicy(ctx context.Context, req *protocol.GetRateLimitPolicyRequest) (rsp *protocol.GetRateLimitPolicyResponse, err error)
+	SetOverride(ctx context.Context, req *protocol.SetRateLimitOverrideRequest) (rsp *protocol.SetRateLimitOverrideResponse, err error)
+	DeleteOverride(ctx context.Context, req *protocol.DeleteRateLimitOverrideRequest) (rsp *protocol.DeleteRateLimitOverrideResponse, err error)
+}
+
+type rateLimitService struct {
+	userDAO *dao.UserDAO
+}
+
+// NewRateLimitService 创建限频策略服务
+//
+//	param userDAO *dao.UserDAO
+//	return RateLimitService
+//	author centonhuang
+//	update 2026-10-18 18:04:14
+func NewRateLimitService(userDAO *dao.UserDAO) RateLimitService {
+	return &rateLimitService{
+		userDAO: userDAO,
+	}
+}
+
+// GetPolicy 获取用户生效的限频策略
+//
+//	receiver s *rateLimitService
+//	param ctx context.Context
+//	param req *protocol.GetRateLimitPolicyRequest
+//	return rsp *protocol.GetRateLimitPolicyResponse
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 16:25:14
+func (s *rateLimitService) GetPolicy(ctx context.Context, req *protocol.GetRateLimitPolicyRequest) (rsp *protocol.GetRateLimitPolicyResponse, err error) {
+	rsp = &protocol.GetRateLimitPolicyResponse{}
+
+	rsp.Policy, err = s.effectivePolicy(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp, nil
+}
+
+// SetOverride 为用户单独设置限频策略
+//
+//	receiver s *rateLimitService
+//	param ctx context.Context
+//	param req *protocol.SetRateLimitOverrideRequest
+//	return rsp *protocol.SetRateLimitOverrideResponse
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 16:25:20
+func (s *rateLimitService) SetOverride(ctx context.Context, req *protocol.SetRateLimitOverrideRequest) (rsp *protocol.SetRateLimitOverrideResponse, err error) {
+	logger := logger.WithCtx(ctx)
+
+	rsp = &protocol.SetRateLimitOverrideResponse{}
+
+	windows, err := fromProtocolWindows(req.Windows)
+	if err != nil {
+		logger.Info("[RateLimitService] invalid rate limit windows", zap.Error(err))
+		return nil, protocol.ErrBadRequest
+	}
+	quotas, err := fromProtocolWindows(req.Quotas)
+	if err != nil {
+		logger.Info("[RateLimitService] invalid rate limit quotas", zap.Error(err))
+		return nil, protocol.ErrBadRequest
+	}
+
+	if _, err = s.getUserPermission(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	if err = ratelimit.SetOverride(ctx, req.UserID, &ratelimit.Policy{Windows: windows, Quotas: quotas}); err != nil {
+		logger.Error("[RateLimitService] failed to set rate limit override", zap.Uint("userID", req.UserID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Policy, err = s.effectivePolicy(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("[RateLimitService] set rate limit override", zap.Uint("userID", req.UserID), zap.Int("windows", len(windows)), zap.Int("quotas", len(quotas)))
+
+	return rsp, nil
+}
+
+// DeleteOverride 删除用户的单独限频策略
+//
+//	receiver s *rateLimitService
+//	param ctx context.Context
+//	param req *protocol.DeleteRateLimitOverrideRequest
+//	return rsp *protocol.DeleteRateLimitOverrideResponse
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 16:25:26
+func (s *rateLimitService) DeleteOverride(ctx context.Context, req *protocol.DeleteRateLimitOverrideRequest) (rsp *protocol.DeleteRateLimitOverrideResponse, err error) {
+	logger := logger.WithCtx(ctx)
+
+	rsp = &protocol.DeleteRateLimitOverrideResponse{}
+
+	if err = ratelimit.DeleteOverride(ctx, req.UserID); err != nil {
+		logger.Error("[RateLimitService] failed to delete rate limit override", zap.Uint("userID", req.UserID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Policy, err = s.effectivePolicy(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("[RateLimitService] delete rate limit override", zap.Uint("userID", req.UserID))
+
+	return rsp, nil
+}
+
+func (s *rateLimitService) getUserPermission(ctx context.Context, userID uint) (model.Permission, error) {
+	logger := logger.WithCtx(ctx)
+	db := database.GetDBInstance(ctx)
+
+	user, err := s.userDAO.GetByID(db, userID, []string{"id", "permission"}, []string{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Info("[RateLimitService] user not found", zap.Uint("userID", userID))
+			return "", protocol.ErrDataNotExists
+		}
+		logger.Error("[RateLimitService] failed to get user by id", zap.Uint("userID", userID), zap.Error(err))
+		return "", protocol.ErrInternalError
+	}
+	return user.Permission, nil
+}
+
+func (s *rateLimitService) effectivePolicy(ctx context.Context, userID uint) (*protocol.RateLimitPolicy, error) {
+	permission, err := s.getUserPermission(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, overridden, err := ratelimit.PolicyFor(ctx, userID, permission)
+	if err != nil {
+		logger.WithCtx(ctx).Error("[RateLimitService] failed to get rate limit policy", zap.Uint("userID", userID), zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	return &protocol.RateLimitPolicy{
+		Windows:    toProtocolWindows(policy.Windows),
+		Quotas:     toProtocolWindows(policy.Quotas),
+		Overridden: overridden,
+	}, nil
+}
+
+var errInvalidRateLimitWindow = errors.New("rate limit window must have a positive period and limit")
+
+func fromProtocolWindows(windows []*protocol.RateLimitWindow) ([]ratelimit.Window, error) {
+	result := make([]ratelimit.Window, 0, len(windows))
+	for _, w := range windows {
+		if w == nil {
+			return nil, errInvalidRateLimitWindow
+		}
+		period, err := time.ParseDuration(w.Period)
+		if err != nil {
+			return nil, err
+		}
+		if period <= 0 || w.Limit <= 0 {
+			return nil, errInvalidRateLimitWindow
+		}
+		result = append(result, ratelimit.Window{Period: period, Limit: w.Limit})
+	}
+	return result, nil
+}
+
+func toProtocolWindows(windows []ratelimit.Window) []*protocol.RateLimitWindow {
+	result := make([]*protocol.RateLimitWindow, 0, len(windows))
+	for _, w := range windows {
+		result = append(result, &protocol.RateLimitWindow{Period: w.Period.String(), Limit: w.Limit})
+	}
+	return result
+}
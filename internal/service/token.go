@@ -30,14 +30,17 @@ type tokenService struct {
 
 // NewTokenService 创建令牌服务
 //
+//	param userDAO *dao.UserDAO
+//	param accessTokenSigner auth.JwtTokenSigner
+//	param refreshTokenSigner auth.JwtTokenSigner
 //	return TokenService
 //	author centonhuang
-//	update 2025-01-04 17:18:59
-func NewTokenService() TokenService {
+//	update 2026-10-18 18:04:02
+func NewTokenService(userDAO *dao.UserDAO, accessTokenSigner, refreshTokenSigner auth.JwtTokenSigner) TokenService {
 	return &tokenService{
-		userDAO:            dao.GetUserDAO(),
-		accessTokenSigner:  auth.GetJwtAccessTokenSigner(),
-		refreshTokenSigner: auth.GetJwtRefreshTokenSigner(),
+		userDAO:            userDAO,
+		accessTokenSigner:  accessTokenSigner,
+		refreshTokenSigner: refreshTokenSigner,
 	}
 }
 
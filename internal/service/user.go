@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/hcd233/go-backend-tmpl/internal/i18n"
 	"github.com/hcd233/go-backend-tmpl/internal/logger"
 	"github.com/hcd233/go-backend-tmpl/internal/protocol"
 	"github.com/hcd233/go-backend-tmpl/internal/resource/database"
@@ -22,6 +23,7 @@ type UserService interface {
 	GetCurUserInfo(ctx context.Context, req *protocol.GetCurUserInfoRequest) (rsp *protocol.GetCurUserInfoResponse, err error)
 	GetUserInfo(ctx context.Context, req *protocol.GetUserInfoRequest) (rsp *protocol.GetUserInfoResponse, err error)
 	UpdateUserInfo(ctx context.Context, req *protocol.UpdateUserInfoRequest) (rsp *protocol.UpdateUserInfoResponse, err error)
+	ListUsers(ctx context.Context, req *protocol.ListUsersRequest) (rsp *protocol.ListUsersResponse, err error)
 }
 
 type userService struct {
@@ -30,12 +32,13 @@ type userService struct {
 
 // NewUserService 创建用户服务
 //
+//	param userDAO *dao.UserDAO
 //	return UserService
 //	author centonhuang
-//	update 2025-01-04 21:03:45
-func NewUserService() UserService {
+//	update 2026-10-18 18:04:08
+func NewUserService(userDAO *dao.UserDAO) UserService {
 	return &userService{
-		userDAO: dao.GetUserDAO(),
+		userDAO: userDAO,
 	}
 }
 
@@ -47,14 +50,14 @@ func NewUserService() UserService {
 //	return rsp *protocol.GetCurUserInfoResponse
 //	return err error
 //	author centonhuang
-//	update 2025-01-04 21:04:03
+//	update 2026-10-18 16:56:26
 func (s *userService) GetCurUserInfo(ctx context.Context, req *protocol.GetCurUserInfoRequest) (rsp *protocol.GetCurUserInfoResponse, err error) {
 	rsp = &protocol.GetCurUserInfoResponse{}
 
 	logger := logger.WithCtx(ctx)
 	db := database.GetDBInstance(ctx)
 
-	user, err := s.userDAO.GetByID(db, req.UserID, []string{"id", "name", "email", "avatar", "created_at", "last_login", "permission"}, []string{})
+	user, err := s.userDAO.GetByID(db, req.UserID, []string{"id", "name", "email", "avatar", "created_at", "last_login", "permission", "locale", "version"}, []string{})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.Error("[UserService] user not found")
@@ -74,7 +77,9 @@ func (s *userService) GetCurUserInfo(ctx context.Context, req *protocol.GetCurUs
 			LastLogin: user.LastLogin.Format(time.DateTime),
 		},
 		Permission: string(user.Permission),
+		Locale:     user.Locale,
 	}
+	rsp.Version = user.Version
 
 	logger.Info("[UserService] get cur user info",
 		zap.String("email", user.Email),
@@ -129,18 +134,119 @@ func (s *userService) GetUserInfo(ctx context.Context, req *protocol.GetUserInfo
 	return rsp, nil
 }
 
+// UpdateUserInfo 更新用户信息，ExpectedVersion 非0时仅当版本号一致才更新
+//
+//	receiver s *userService
+//	param ctx context.Context
+//	param req *protocol.UpdateUserInfoRequest
+//	return rsp *protocol.UpdateUserInfoResponse
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 16:56:26
 func (s *userService) UpdateUserInfo(ctx context.Context, req *protocol.UpdateUserInfoRequest) (rsp *protocol.UpdateUserInfoResponse, err error) {
 	logger := logger.WithCtx(ctx)
 
 	rsp = &protocol.UpdateUserInfoResponse{}
-	db := database.GetDBInstance(ctx)
 
-	if err := s.userDAO.Update(db, &model.User{BaseModel: model.BaseModel{ID: req.UserID}}, map[string]interface{}{
+	user := &model.User{BaseModel: model.BaseModel{ID: req.UserID}}
+	info := map[string]interface{}{
 		"name": req.UpdatedUserName,
-	}); err != nil {
+	}
+	if locale, ok := i18n.Normalize(req.UpdatedLocale); ok {
+		info["locale"] = locale
+	}
+
+	// 更新与读取新版本号在同一事务中，保证返回的版本号就是本次写入的版本
+	err = database.WithTx(ctx, func(ctx context.Context) error {
+		db := database.GetDBInstance(ctx)
+		if req.ExpectedVersion != 0 {
+			return s.userDAO.UpdateWithVersion(db, user, req.ExpectedVersion, info)
+		}
+
+		if err := s.userDAO.Update(db, user, info); err != nil {
+			return err
+		}
+		updated, err := s.userDAO.GetByID(db, req.UserID, []string{"id", "version"}, []string{})
+		if err != nil {
+			return err
+		}
+		user.Version = updated.Version
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, dao.ErrVersionConflict):
+			logger.Info("[UserService] user version conflict", zap.Error(err))
+			return nil, protocol.ErrConflict
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			logger.Error("[UserService] user not found")
+			return nil, protocol.ErrDataNotExists
+		}
 		logger.Error("[UserService] failed to update user", zap.Error(err))
 		return nil, protocol.ErrInternalError
 	}
 
+	rsp.Version = user.Version
+	return rsp, nil
+}
+
+// ListUsers 按过滤条件与排序游标分页列出用户
+//
+//	receiver s *userService
+//	param ctx context.Context
+//	param req *protocol.ListUsersRequest
+//	return rsp *protocol.ListUsersResponse
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 14:47:02
+func (s *userService) ListUsers(ctx context.Context, req *protocol.ListUsersRequest) (rsp *protocol.ListUsersResponse, err error) {
+	logger := logger.WithCtx(ctx)
+
+	rsp = &protocol.ListUsersResponse{}
+	db := database.GetDBInstance(ctx)
+
+	param := &dao.ListParam{
+		Sort:   req.Param.Sort,
+		Cursor: req.Param.Cursor,
+		Limit:  req.Param.Limit,
+	}
+	for _, filter := range req.Param.Filters {
+		param.Filters = append(param.Filters, dao.Filter{Field: filter.Field, Op: dao.FilterOp(filter.Op), Value: filter.Value})
+	}
+
+	users, pageInfo, err := s.userDAO.List(db, []string{"id", "name", "email", "avatar", "created_at", "last_login", "permission"}, []string{}, dao.UserListSpec, param)
+	if err != nil {
+		if errors.Is(err, dao.ErrInvalidListParam) {
+			logger.Info("[UserService] invalid list param", zap.Error(err))
+			return nil, protocol.ErrBadRequest
+		}
+		logger.Error("[UserService] failed to list users", zap.Error(err))
+		return nil, protocol.ErrInternalError
+	}
+
+	rsp.Items = make([]*protocol.CurUser, 0, len(users))
+	for _, user := range users {
+		rsp.Items = append(rsp.Items, &protocol.CurUser{
+			User: protocol.User{
+				UserID:    user.ID,
+				Name:      user.Name,
+				Email:     user.Email,
+				Avatar:    user.Avatar,
+				CreatedAt: user.CreatedAt.Format(time.DateTime),
+				LastLogin: user.LastLogin.Format(time.DateTime),
+			},
+			Permission: string(user.Permission),
+		})
+	}
+	rsp.PageInfo = &protocol.CursorPageInfo{
+		Limit:      pageInfo.Limit,
+		HasNext:    pageInfo.HasNext,
+		HasPrev:    pageInfo.HasPrev,
+		NextCursor: pageInfo.NextCursor,
+		PrevCursor: pageInfo.PrevCursor,
+	}
+
+	logger.Info("[UserService] list users", zap.Int("count", len(rsp.Items)), zap.Bool("hasNext", pageInfo.HasNext))
+
 	return rsp, nil
 }
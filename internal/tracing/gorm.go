@@ -0,0 +1,106 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "tracing:span"
+
+// gormSystems gorm 方言名到 db.system.name 的映射
+var gormSystems = map[string]attribute.KeyValue{
+	"postgres": semconv.DBSystemNamePostgreSQL,
+	"mysql":    semconv.DBSystemNameMySQL,
+	"sqlite":   semconv.DBSystemNameSQLite,
+}
+
+// GormPlugin 为 GORM 的增删改查与原生SQL创建客户端 Span，只记录带占位符的SQL，不记录参数
+//
+//	author centonhuang
+//	update 2026-10-18 17:03:02
+type GormPlugin struct{}
+
+// Name 插件名
+//
+//	receiver p *GormPlugin
+//	return string
+//	author centonhuang
+//	update 2026-10-18 17:03:08
+func (p *GormPlugin) Name() string {
+	return "tracing"
+}
+
+// Initialize 注册回调
+//
+//	receiver p *GormPlugin
+//	param db *gorm.DB
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:03:14
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	system, ok := gormSystems[db.Dialector.Name()]
+	if !ok {
+		system = semconv.DBSystemNameKey.String(db.Dialector.Name())
+	}
+
+	callbacks := db.Callback()
+	processors := []struct {
+		operation string
+		before    func(name string, fn func(*gorm.DB)) error
+		after     func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", callbacks.Create().Before("gorm:create").Register, callbacks.Create().After("gorm:create").Register},
+		{"query", callbacks.Query().Before("gorm:query").Register, callbacks.Query().After("gorm:query").Register},
+		{"update", callbacks.Update().Before("gorm:update").Register, callbacks.Update().After("gorm:update").Register},
+		{"delete", callbacks.Delete().Before("gorm:delete").Register, callbacks.Delete().After("gorm:delete").Register},
+		{"row", callbacks.Row().Before("gorm:row").Register, callbacks.Row().After("gorm:row").Register},
+		{"raw", callbacks.Raw().Before("gorm:raw").Register, callbacks.Raw().After("gorm:raw").Register},
+	}
+	for _, processor := range processors {
+		if err := processor.before("tracing:before_"+processor.operation, p.before(processor.operation, system)); err != nil {
+			return err
+		}
+		if err := processor.after("tracing:after_"+processor.operation, p.after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *GormPlugin) before(operation string, system attribute.KeyValue) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement.Context == nil {
+			return
+		}
+		_, span := Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(system, semconv.DBOperationName(operation)),
+		)
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func (p *GormPlugin) after(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		semconv.DBCollectionName(db.Statement.Table),
+		semconv.DBResponseReturnedRows(int(db.Statement.RowsAffected)),
+	)
+	// 记录不存在属于业务结果，不标记为错误
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Transport 为出站 HTTP 请求创建客户端 Span 并注入 traceparent 请求头
+//
+//	author centonhuang
+//	update 2026-10-18 17:01:02
+type Transport struct {
+	base   http.RoundTripper
+	system string
+}
+
+// NewTransport 包装 HTTP Transport
+//
+//	param base http.RoundTripper 为 nil 时使用 http.DefaultTransport
+//	param system string 下游系统名，作为 Span 名前缀，如 minio、cos、openai
+//	return *Transport
+//	author centonhuang
+//	update 2026-10-18 17:01:08
+func NewTransport(base http.RoundTripper, system string) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{base: base, system: system}
+}
+
+// RoundTrip 实现 http.RoundTripper
+//
+//	receiver t *Transport
+//	param req *http.Request
+//	return *http.Response
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:01:14
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := Start(req.Context(), t.system+" "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.ServerAddress(req.URL.Hostname()),
+			semconv.URLPath(req.URL.Path),
+		),
+	)
+	defer span.End()
+
+	// RoundTripper 不能修改原请求
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	rsp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(rsp.StatusCode))
+	if rsp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(rsp.StatusCode))
+	}
+	return rsp, nil
+}
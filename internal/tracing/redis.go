@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedisHook 为 Redis 命令与流水线创建客户端 Span，命令参数可能包含敏感数据，只记录命令名
+//
+//	author centonhuang
+//	update 2026-10-18 17:02:02
+type RedisHook struct{}
+
+// DialHook 实现 redis.Hook
+//
+//	receiver RedisHook
+//	param next redis.DialHook
+//	return redis.DialHook
+//	author centonhuang
+//	update 2026-10-18 17:02:08
+func (RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		// 连接池后台建连不属于任何请求，不单独开启链路
+		if !trace.SpanContextFromContext(ContextWithSpan(ctx)).IsValid() {
+			return next(ctx, network, addr)
+		}
+		ctx, span := startRedisSpan(ctx, "redis dial")
+		defer span.End()
+
+		conn, err := next(ctx, network, addr)
+		endRedisSpan(span, err)
+		return conn, err
+	}
+}
+
+// ProcessHook 实现 redis.Hook
+//
+//	receiver RedisHook
+//	param next redis.ProcessHook
+//	return redis.ProcessHook
+//	author centonhuang
+//	update 2026-10-18 17:02:14
+func (RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := startRedisSpan(ctx, "redis "+cmd.Name(), semconv.DBOperationName(cmd.Name()))
+		defer span.End()
+
+		err := next(ctx, cmd)
+		endRedisSpan(span, err)
+		return err
+	}
+}
+
+// ProcessPipelineHook 实现 redis.Hook
+//
+//	receiver RedisHook
+//	param next redis.ProcessPipelineHook
+//	return redis.ProcessPipelineHook
+//	author centonhuang
+//	update 2026-10-18 17:02:20
+func (RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		names := make([]string, 0, len(cmds))
+		for _, cmd := range cmds {
+			names = append(names, cmd.Name())
+		}
+		ctx, span := startRedisSpan(ctx, "redis pipeline", semconv.DBOperationName("pipeline "+strings.Join(names, " ")))
+		defer span.End()
+
+		err := next(ctx, cmds)
+		endRedisSpan(span, err)
+		return err
+	}
+}
+
+func startRedisSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemNameRedis),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+func endRedisSpan(span trace.Span, err error) {
+	// 键不存在不是错误
+	if err == nil || errors.Is(err, redis.Nil) {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,140 @@
+// Package tracing OpenTelemetry 链路追踪
+//
+//	入站请求由 TraceMiddleware 按 W3C traceparent 续接或开启链路，出站的数据库、Redis 与 HTTP 调用
+//	通过本包的插件、钩子与 Transport 创建子 Span 并向下游传递 traceparent。
+//	配置 OTEL_EXPORTER_OTLP_ENDPOINT 后以 OTLP HTTP 上报，未配置时仍生成链路ID用于日志关联。
+//
+//	update 2026-10-18 17:00:02
+package tracing
+
+import (
+	"context"
+
+	"github.com/hcd233/go-backend-tmpl/internal/config"
+	"github.com/hcd233/go-backend-tmpl/internal/constant"
+	"github.com/hcd233/go-backend-tmpl/internal/lifecycle"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
+	"github.com/samber/lo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// instrumentationName 本服务创建 Span 使用的 Tracer 名称
+const instrumentationName = "github.com/hcd233/go-backend-tmpl"
+
+var provider *sdktrace.TracerProvider
+
+// InitTracing 初始化全局 TracerProvider 与 W3C 传播器
+//
+//	author centonhuang
+//	update 2026-10-18 17:00:08
+func InitTracing() {
+	res := lo.Must1(resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.OtelServiceName),
+		semconv.DeploymentEnvironmentName(config.AppEnv),
+	)))
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.OtelTracesSamplerArg))),
+	}
+	if config.OtelExporterOTLPEndpoint != "" {
+		exporter := lo.Must1(otlptracehttp.New(context.Background(),
+			otlptracehttp.WithEndpointURL(config.OtelExporterOTLPEndpoint),
+			otlptracehttp.WithHeaders(config.OtelExporterOTLPHeaders),
+		))
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	provider = sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if config.OtelExporterOTLPEndpoint == "" {
+		logger.Logger().Warn("[Tracing] OTLP endpoint is not configured, spans will not be exported")
+		return
+	}
+	logger.Logger().Info("[Tracing] Exporting spans via OTLP HTTP",
+		zap.String("endpoint", config.OtelExporterOTLPEndpoint),
+		zap.String("serviceName", config.OtelServiceName),
+		zap.Float64("samplerArg", config.OtelTracesSamplerArg))
+}
+
+// Shutdown 上报缓冲中的 Span 并关闭 TracerProvider
+//
+//	param ctx context.Context
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:00:14
+func Shutdown(ctx context.Context) error {
+	if provider == nil {
+		return nil
+	}
+	return provider.Shutdown(ctx)
+}
+
+// LifecycleHook 链路追踪的启动与停止逻辑，停止时上报缓冲中的 Span，需最后停止
+//
+//	return lifecycle.Hook
+//	author centonhuang
+//	update 2026-10-18 17:44:02
+func LifecycleHook() lifecycle.Hook {
+	return lifecycle.Hook{
+		Name: "tracing",
+		OnStart: func(context.Context) error {
+			InitTracing()
+			return nil
+		},
+		OnStop: Shutdown,
+	}
+}
+
+// Tracer 返回本服务的 Tracer，未初始化时为全局的空实现
+//
+//	return trace.Tracer
+//	author centonhuang
+//	update 2026-10-18 17:00:20
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start 以上下文中的 Span 为父 Span 创建子 Span
+//
+//	上下文为 Fiber 请求上下文（c.Context()）或由其派生时，父 Span 取自 TraceMiddleware 保存在 Locals 中的 Span；
+//	TraceMiddleware 设置的 c.UserContext() 本身已携带请求 Span
+//
+//	param ctx context.Context
+//	param name string
+//	param opts ...trace.SpanStartOption
+//	return context.Context
+//	return trace.Span
+//	author centonhuang
+//	update 2026-10-18 17:47:08
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ContextWithSpan(ctx), name, opts...)
+}
+
+// ContextWithSpan 将 Locals 中保存的请求 Span 放入标准的 OpenTelemetry 上下文
+//
+//	c.Context() 中的值来自 Fiber Locals，OpenTelemetry 无法直接取到 Span
+//
+//	param ctx context.Context
+//	return context.Context
+//	author centonhuang
+//	update 2026-10-18 17:47:14
+func ContextWithSpan(ctx context.Context) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	if span, ok := ctx.Value(constant.CtxKeySpan).(trace.Span); ok {
+		return trace.ContextWithSpan(ctx, span)
+	}
+	return ctx
+}
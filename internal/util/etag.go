@@ -0,0 +1,47 @@
+package util
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidETag If-Match 请求头格式错误
+//
+//	update 2026-10-18 14:25:08
+var ErrInvalidETag = errors.New("invalid etag")
+
+// FormatETag 由版本号生成弱ETag，压缩等传输编码不影响其语义
+//
+//	param version uint
+//	return string
+//	author centonhuang
+//	update 2026-10-18 14:25:14
+func FormatETag(version uint) string {
+	return `W/"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+// ParseIfMatch 解析 If-Match 请求头中的版本号，未携带或为 * 时返回0，表示不做条件更新
+//
+//	param header string
+//	return version uint
+//	return err error
+//	author centonhuang
+//	update 2026-10-18 14:25:20
+func ParseIfMatch(header string) (version uint, err error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, ErrInvalidETag
+	}
+
+	parsed, err := strconv.ParseUint(tag[1:len(tag)-1], 10, 64)
+	if err != nil || parsed == 0 {
+		return 0, ErrInvalidETag
+	}
+	return uint(parsed), nil
+}
@@ -1,46 +1,126 @@
 package util
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/hcd233/go-backend-tmpl/internal/constant"
+	"github.com/hcd233/go-backend-tmpl/internal/i18n"
+	"github.com/hcd233/go-backend-tmpl/internal/logger"
 	"github.com/hcd233/go-backend-tmpl/internal/protocol"
+	"go.uber.org/zap"
 )
 
-// SendHTTPResponse 发送HTTP响应
+// MIMEApplicationProblemJSON RFC 7807 错误响应的内容类型
+//
+//	update 2026-10-18 16:42:02
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// problemTypeBlank RFC 7807 中表示错误含义仅由状态码决定的类型，错误类型由扩展字段 code 区分
+const problemTypeBlank = "about:blank"
+
+// SendHTTPResponse 发送成功响应
+//
+//	param c *fiber.Ctx
+//	param data interface{}
+//	return error
+//	author centonhuang
+//	update 2026-10-18 16:42:08
+func SendHTTPResponse(c *fiber.Ctx, data interface{}) error {
+	return c.Status(http.StatusOK).JSON(protocol.HTTPResponse{Data: data})
+}
+
+// SendHTTPResponseWithStatus 以指定状态码发送响应体，用于就绪检查等状态码本身即表示结果的场景
 //
 //	param c *fiber.Ctx
+//	param status int
 //	param data interface{}
+//	return error
+//	author centonhuang
+//	update 2026-10-18 17:52:20
+func SendHTTPResponseWithStatus(c *fiber.Ctx, status int, data interface{}) error {
+	return c.Status(status).JSON(protocol.HTTPResponse{Data: data})
+}
+
+// SendHTTPError 发送错误响应，所有错误响应都经由此处写出
+//
+//	非 protocol.Error 的错误按内部错误处理；说明按协商的语言翻译并设置 Content-Language；
+//	客户端 Accept 为 application/problem+json 时以 RFC 7807 格式返回，否则返回标准响应体。
+//
+//	param c *fiber.Ctx
+//	param err error
+//	return error
+//	author centonhuang
+//	update 2026-10-18 16:54:02
+func SendHTTPError(c *fiber.Ctx, err error) error {
+	apiErr := AsError(err)
+
+	locale := i18n.Negotiate(c)
+	message := apiErr.Message
+	if apiErr.MessageKey != "" {
+		if translated, ok := i18n.Translate(locale, apiErr.MessageKey, apiErr.MessageArgs); ok {
+			message = translated
+		}
+	}
+	c.Set(fiber.HeaderContentLanguage, locale)
+
+	if c.Accepts(fiber.MIMEApplicationJSON, MIMEApplicationProblemJSON) == MIMEApplicationProblemJSON {
+		problem := protocol.ProblemDetails{
+			Type:     problemTypeBlank,
+			Title:    http.StatusText(apiErr.Status),
+			Status:   apiErr.Status,
+			Detail:   message,
+			Instance: c.OriginalURL(),
+			Code:     apiErr.Code,
+			Errors:   apiErr.Details,
+		}
+		if traceID, ok := c.Locals(constant.CtxKeyTraceID).(string); ok {
+			problem.TraceID = traceID
+		}
+		return c.Status(apiErr.Status).JSON(problem, MIMEApplicationProblemJSON)
+	}
+
+	return c.Status(apiErr.Status).JSON(protocol.HTTPResponse{
+		Error:   apiErr.Code,
+		Message: message,
+		Details: apiErr.Details,
+	})
+}
+
+// ErrorHandler Fiber 的错误处理函数，处理器与中间件直接返回错误即可
+//
+//	param c *fiber.Ctx
 //	param err error
+//	return error
 //	author centonhuang
-//	update 2025-01-04 17:34:06
-func SendHTTPResponse(c *fiber.Ctx, data interface{}, err error) error {
-	status := http.StatusOK
-	rsp := protocol.HTTPResponse{}
-
-	if err == nil {
-		rsp.Data = data
-		return c.Status(status).JSON(rsp)
+//	update 2026-10-18 16:42:20
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	apiErr := AsError(err)
+	if apiErr.Status >= http.StatusInternalServerError {
+		logger.WithFCtx(c).Error("[ErrorHandler] request failed", zap.String("code", apiErr.Code), zap.Error(err))
 	}
-	rsp.Error = err.Error()
-
-	switch err {
-	case protocol.ErrDataNotExists, protocol.ErrDataExists: // 200
-	case protocol.ErrBadRequest: // 400
-		status = http.StatusBadRequest
-	case protocol.ErrBadRequest: // 400
-		status = http.StatusBadRequest
-	case protocol.ErrUnauthorized: // 401
-		status = http.StatusUnauthorized
-	case protocol.ErrNoPermission, protocol.ErrInsufficientQuota: // 403
-		status = http.StatusForbidden
-	case protocol.ErrTooManyRequests: // 429
-		status = http.StatusTooManyRequests
-	case protocol.ErrInternalError: // 500
-		status = http.StatusInternalServerError
-	case protocol.ErrNoImplement: // 501
-		status = http.StatusNotImplemented
+	return SendHTTPError(c, apiErr)
+}
+
+// AsError 将错误转换为 protocol.Error
+//
+//	Fiber 的错误按状态码转换，其余错误包装为内部错误
+//
+//	param err error
+//	return *protocol.Error
+//	author centonhuang
+//	update 2026-10-18 16:42:26
+func AsError(err error) *protocol.Error {
+	var apiErr *protocol.Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return protocol.FromStatus(fiberErr.Code, fiberErr.Message)
 	}
 
-	return c.Status(status).JSON(rsp)
+	return protocol.ErrInternalError.WithCause(err)
 }
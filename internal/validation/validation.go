@@ -0,0 +1,162 @@
+// Package validation 请求参数校验
+//
+//	按 binding 标签校验请求结构体，校验失败时返回逐字段的错误详情。
+//	错误信息优先取自 i18n 消息目录的 validation.<rule>[.<kind>] 条目，
+//	目录缺失的规则回退到 validator 内置的翻译。
+//
+//	update 2026-10-18 16:55:02
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/zh"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+	zhtranslations "github.com/go-playground/validator/v10/translations/zh"
+	"github.com/hcd233/go-backend-tmpl/internal/i18n"
+	"github.com/hcd233/go-backend-tmpl/internal/protocol"
+	"github.com/hcd233/go-backend-tmpl/internal/util"
+	"github.com/samber/lo"
+)
+
+const (
+	tagName = "binding"
+
+	messageKeyPrefix = "validation."
+
+	kindString = "string"
+	kindSlice  = "slice"
+	kindNumber = "number"
+)
+
+// fieldNameTags 错误详情中的字段名依次取自这些标签，与客户端看到的名字一致
+var fieldNameTags = []string{"json", "query", "params", "uri", "form"}
+
+// customRules 自定义校验规则，错误信息见消息目录的 validation.<rule>
+var customRules = map[string]validator.Func{
+	"username": func(fl validator.FieldLevel) bool {
+		return util.ValidateUserName(fl.Field().String()) == nil
+	},
+	"duration": func(fl validator.FieldLevel) bool {
+		d, err := time.ParseDuration(fl.Field().String())
+		return err == nil && d > 0
+	},
+	"locale": func(fl validator.FieldLevel) bool {
+		_, ok := i18n.Normalize(fl.Field().String())
+		return ok
+	},
+}
+
+var (
+	validate = validator.New(validator.WithRequiredStructEnabled())
+
+	uni = ut.New(en.New(), en.New(), zh.New())
+)
+
+func init() {
+	validate.SetTagName(tagName)
+	validate.RegisterTagNameFunc(fieldName)
+
+	enTrans, _ := uni.GetTranslator(i18n.LocaleEN)
+	zhTrans, _ := uni.GetTranslator(i18n.LocaleZH)
+	lo.Must0(entranslations.RegisterDefaultTranslations(validate, enTrans))
+	lo.Must0(zhtranslations.RegisterDefaultTranslations(validate, zhTrans))
+
+	for tag, fn := range customRules {
+		lo.Must0(validate.RegisterValidation(tag, fn))
+	}
+}
+
+// Struct 按 binding 标签校验结构体
+//
+//	param obj interface{}
+//	return error 校验失败时为 validator.ValidationErrors
+//	author centonhuang
+//	update 2026-10-18 16:30:14
+func Struct(obj interface{}) error {
+	return validate.Struct(obj)
+}
+
+// Details 将校验错误转换为逐字段的错误详情，非校验错误返回 nil
+//
+//	param err error
+//	param locale string i18n 支持的语言
+//	return []*protocol.FieldError
+//	author centonhuang
+//	update 2026-10-18 16:55:08
+func Details(err error, locale string) []*protocol.FieldError {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil
+	}
+
+	trans, _ := uni.GetTranslator(locale)
+	details := make([]*protocol.FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		details = append(details, &protocol.FieldError{
+			Field:   fieldPath(fe.Namespace()),
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: message(fe, locale, trans),
+		})
+	}
+	return details
+}
+
+// message 翻译单个字段的错误信息
+//
+//	依次查找 validation.<rule>.<kind> 与 validation.<rule>，字符串与列表的长度规则以参数作为数量选择复数形式
+func message(fe validator.FieldError, locale string, trans ut.Translator) string {
+	kind := kindOf(fe.Kind())
+	args := i18n.Args{"field": fe.Field(), "param": fe.Param()}
+	if kind == kindString || kind == kindSlice {
+		if count, err := strconv.ParseInt(fe.Param(), 10, 64); err == nil {
+			args["count"] = count
+		}
+	}
+
+	for _, key := range []string{messageKeyPrefix + fe.Tag() + "." + kind, messageKeyPrefix + fe.Tag()} {
+		if text, ok := i18n.Translate(locale, key, args); ok {
+			return text
+		}
+	}
+	return fe.Translate(trans)
+}
+
+// kindOf 将字段类型归为消息目录中的 string、slice 或 number
+func kindOf(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return kindString
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return kindSlice
+	default:
+		return kindNumber
+	}
+}
+
+// fieldName 取字段在请求中的名字，标签为 - 时返回空以使用结构体字段名
+func fieldName(field reflect.StructField) string {
+	for _, tag := range fieldNameTags {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return ""
+}
+
+// fieldPath 去掉命名空间中的顶层结构体名，如 SetRateLimitOverrideBody.windows[0].period -> windows[0].period
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}